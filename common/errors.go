@@ -0,0 +1,61 @@
+package common
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbonetwork/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error is an error of the service request or its item. Code is sent to the client in BaseResponse
+// of the item and is converted to gRPC status if the whole request fails
+type Error struct {
+	Code pb.StatusCode
+	Msg  string
+}
+
+// NewError constructs Error with formatted message
+func NewError(code pb.StatusCode, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+// GRPCStatus allows gRPC to send Error to the client as status with the corresponding code
+func (e *Error) GRPCStatus() *status.Status {
+	return status.New(GetGRPCCode(e.Code), e.Msg)
+}
+
+// GetCode returns code of err if it is an Error and defaultCode otherwise
+func GetCode(err error, defaultCode pb.StatusCode) pb.StatusCode {
+	if e, ok := err.(*Error); ok {
+		return e.Code
+	}
+	return defaultCode
+}
+
+// GetGRPCCode maps service status code to the closest gRPC code
+func GetGRPCCode(code pb.StatusCode) codes.Code {
+	switch code {
+	case pb.StatusCode_OK:
+		return codes.OK
+	case pb.StatusCode_BAD_REQUEST:
+		return codes.InvalidArgument
+	case pb.StatusCode_NOT_FOUND:
+		return codes.NotFound
+	case pb.StatusCode_CONFLICT:
+		return codes.AlreadyExists
+	case pb.StatusCode_FAILED_DEPENDENCY:
+		return codes.Aborted
+	case pb.StatusCode_CANCELLED:
+		return codes.Canceled
+	case pb.StatusCode_INTERNAL_ERROR:
+		return codes.Internal
+	case pb.StatusCode_DEADLINE_EXCEEDED:
+		return codes.DeadlineExceeded
+	default:
+		return codes.Unknown
+	}
+}
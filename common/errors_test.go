@@ -0,0 +1,31 @@
+package common
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestError_GRPCStatus(t *testing.T) {
+	var err error = NewError(pb.StatusCode_NOT_FOUND, "node %d not found", 1)
+	assert.Equal(t, "node 1 not found", err.Error())
+
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "node 1 not found", st.Message())
+}
+
+func TestGetCode(t *testing.T) {
+	assert.Equal(t, pb.StatusCode_CONFLICT, GetCode(NewError(pb.StatusCode_CONFLICT, "conflict"), pb.StatusCode_INTERNAL_ERROR))
+	assert.Equal(t, pb.StatusCode_INTERNAL_ERROR, GetCode(fmt.Errorf("err"), pb.StatusCode_INTERNAL_ERROR))
+}
+
+func TestGetGRPCCode(t *testing.T) {
+	assert.Equal(t, codes.OK, GetGRPCCode(pb.StatusCode_OK))
+	assert.Equal(t, codes.Internal, GetGRPCCode(pb.StatusCode_INTERNAL_ERROR))
+	assert.Equal(t, codes.Unknown, GetGRPCCode(pb.StatusCode_UNSET))
+}
@@ -0,0 +1,214 @@
+// Package interceptors contains gRPC server interceptors shared by the node and network services.
+// They recover panics of the handlers, assign request ids and log every request
+package interceptors
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/common/metrics"
+	"github.com/Sovianum/turbonetwork/pb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"runtime/debug"
+	"time"
+)
+
+// RequestIDKey is a metadata key of the request id. Id sent by the client is reused if it matches
+// requestIDPattern, otherwise the new one is generated. Id is returned to the client in response header
+const RequestIDKey = "x-request-id"
+
+// requestIDPattern limits length and characters of the client request ids, because ids are written
+// to the log as is and must not be able to break its lines (e.g. with newlines or spaces)
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+type requestIDCtxKey struct{}
+
+// Config contains parameters of the interceptors
+type Config struct {
+	// Logger receives request lines and panic reports, standard logger is used if it is nil
+	Logger *log.Logger
+	// ErrorsOnly disables logging of successful requests
+	ErrorsOnly bool
+	// Registry collects RPC latencies, latencies are not collected if it is nil
+	Registry *metrics.Registry
+	// Guard is called with full method name before the handler. Request is rejected
+	// with the returned error if it is not nil. All requests are accepted if Guard is nil
+	Guard func(method string) error
+}
+
+func (c Config) getLogger() *log.Logger {
+	if c.Logger == nil {
+		return log.New(os.Stderr, "", log.LstdFlags)
+	}
+	return c.Logger
+}
+
+func (c Config) check(method string) error {
+	if c.Guard == nil {
+		return nil
+	}
+	return c.Guard(method)
+}
+
+// ServerOptions returns options which install both unary and stream interceptors to the server
+func ServerOptions(config Config) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(NewUnaryServerInterceptor(config)),
+		grpc.StreamInterceptor(NewStreamServerInterceptor(config)),
+	}
+}
+
+// NewUnaryServerInterceptor constructs interceptor which recovers panics of the handler
+// and logs method, number of request items, duration and status of every request
+func NewUnaryServerInterceptor(config Config) grpc.UnaryServerInterceptor {
+	logger, latency := config.getLogger(), config.Registry.NewRPCLatency()
+	return func(
+		ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		ctx, requestID := withRequestID(ctx)
+		start := time.Now()
+
+		defer func() {
+			if r := recover(); r != nil {
+				err = getPanicErr(logger, requestID, info.FullMethod, r)
+			}
+			duration := time.Since(start)
+			latency.Observe(info.FullMethod, duration.Seconds())
+			if err != nil || !config.ErrorsOnly {
+				logRequest(logger, requestID, info.FullMethod, getItemCount(req), duration, err)
+			}
+		}()
+
+		if err := config.check(info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NewStreamServerInterceptor works as NewUnaryServerInterceptor for streaming methods.
+// Items of all the received messages are counted
+func NewStreamServerInterceptor(config Config) grpc.StreamServerInterceptor {
+	logger, latency := config.getLogger(), config.Registry.NewRPCLatency()
+	return func(
+		srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+	) (err error) {
+		ctx, requestID := withRequestID(stream.Context())
+		wrapped := &serverStream{ServerStream: stream, ctx: ctx}
+		start := time.Now()
+
+		defer func() {
+			if r := recover(); r != nil {
+				err = getPanicErr(logger, requestID, info.FullMethod, r)
+			}
+			duration := time.Since(start)
+			latency.Observe(info.FullMethod, duration.Seconds())
+			if err != nil || !config.ErrorsOnly {
+				logRequest(logger, requestID, info.FullMethod, wrapped.itemCnt, duration, err)
+			}
+		}()
+
+		if err := config.check(info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, wrapped)
+	}
+}
+
+// GetRequestID returns id of the request ctx belongs to or empty string if it is not set
+func GetRequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDCtxKey{}).(string)
+	return id
+}
+
+// Logf writes line prefixed with id of the request ctx belongs to
+func Logf(logger *log.Logger, ctx context.Context, format string, args ...interface{}) {
+	logger.Printf("request_id=%s %s", GetRequestID(ctx), fmt.Sprintf(format, args...))
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	itemCnt int
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.itemCnt += getItemCount(m)
+	}
+	return err
+}
+
+// withRequestID saves request id from incoming metadata (or generated one) to ctx
+// and sends it back to the client in response header
+func withRequestID(ctx context.Context) (context.Context, string) {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDKey); len(ids) > 0 {
+			requestID = ids[0]
+		}
+	}
+	if !requestIDPattern.MatchString(requestID) {
+		requestID = newRequestID()
+	}
+
+	// header can not be set if ctx does not belong to a server transport (e.g. in tests)
+	grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, requestID))
+	return context.WithValue(ctx, requestIDCtxKey{}, requestID), requestID
+}
+
+func newRequestID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// getPanicErr converts value recovered in the handler to internal error.
+// Stack trace is written to the server log and is not sent to the client
+func getPanicErr(logger *log.Logger, requestID, method string, r interface{}) error {
+	logger.Printf("request_id=%s method=%s panic=\"%v\"\n%s", requestID, method, r, debug.Stack())
+	return common.NewError(pb.StatusCode_INTERNAL_ERROR, "%v", r)
+}
+
+func logRequest(logger *log.Logger, requestID, method string, itemCnt int, duration time.Duration, err error) {
+	line := fmt.Sprintf(
+		"request_id=%s method=%s items=%d duration=%s status=%s",
+		requestID, method, itemCnt, duration, status.Code(err),
+	)
+	if err != nil {
+		line += fmt.Sprintf(" error=\"%s\"", err.Error())
+	}
+	logger.Print(line)
+}
+
+// getItemCount returns length of the Items or Ids field of the request message, 0 if it has none
+func getItemCount(req interface{}) int {
+	val := reflect.Indirect(reflect.ValueOf(req))
+	if val.Kind() != reflect.Struct {
+		return 0
+	}
+	for _, name := range []string{"Items", "Ids"} {
+		if field := val.FieldByName(name); field.IsValid() && field.Kind() == reflect.Slice {
+			return field.Len()
+		}
+	}
+	return 0
+}
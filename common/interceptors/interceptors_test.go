@@ -0,0 +1,201 @@
+package interceptors
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Sovianum/turbonetwork/common/metrics"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"log"
+	"strings"
+	"testing"
+)
+
+type InterceptorsTestSuite struct {
+	suite.Suite
+	buf    *bytes.Buffer
+	logger *log.Logger
+}
+
+func (s *InterceptorsTestSuite) SetupTest() {
+	s.buf = &bytes.Buffer{}
+	s.logger = log.New(s.buf, "", 0)
+}
+
+func (s *InterceptorsTestSuite) TestUnary_Success() {
+	var handlerRequestID string
+	interceptor := NewUnaryServerInterceptor(Config{Logger: s.logger})
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "req-1"))
+
+	resp, err := interceptor(
+		ctx, &pb.NodeIdentifiers{Ids: make([]*pb.NodeIdentifier, 3)}, s.getUnaryInfo(),
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			handlerRequestID = GetRequestID(ctx)
+			Logf(s.logger, ctx, "handled")
+			return "resp", nil
+		},
+	)
+
+	s.Require().Nil(err)
+	s.Equal("resp", resp)
+	s.Equal("req-1", handlerRequestID)
+
+	lines := s.getLogLines()
+	s.Require().Equal(2, len(lines))
+	s.Equal("request_id=req-1 handled", lines[0])
+	s.True(strings.HasPrefix(lines[1], "request_id=req-1 method=/test/Method items=3 duration="), lines[1])
+	s.True(strings.HasSuffix(lines[1], "status=OK"), lines[1])
+}
+
+func (s *InterceptorsTestSuite) TestUnary_GeneratedRequestID() {
+	interceptor := NewUnaryServerInterceptor(Config{Logger: s.logger})
+
+	var requestIDs []string
+	for i := 0; i != 2; i++ {
+		interceptor(context.Background(), nil, s.getUnaryInfo(), func(ctx context.Context, req interface{}) (interface{}, error) {
+			requestIDs = append(requestIDs, GetRequestID(ctx))
+			return nil, nil
+		})
+	}
+	s.NotEqual("", requestIDs[0])
+	s.NotEqual(requestIDs[0], requestIDs[1])
+}
+
+func (s *InterceptorsTestSuite) TestUnary_InvalidRequestID() {
+	interceptor := NewUnaryServerInterceptor(Config{Logger: s.logger})
+
+	for _, clientID := range []string{"req-1\nrequest_id=forged", "req 1", strings.Repeat("a", 65)} {
+		var requestID string
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, clientID))
+		interceptor(ctx, nil, s.getUnaryInfo(), func(ctx context.Context, req interface{}) (interface{}, error) {
+			requestID = GetRequestID(ctx)
+			return nil, nil
+		})
+		s.NotEqual("", requestID)
+		s.NotEqual(clientID, requestID)
+	}
+	s.Equal(3, len(s.getLogLines()))
+	s.NotContains(s.buf.String(), "forged")
+}
+
+func (s *InterceptorsTestSuite) TestUnary_Latency() {
+	registry := metrics.NewRegistry()
+	interceptor := NewUnaryServerInterceptor(Config{Logger: s.logger, Registry: registry})
+	interceptor(context.Background(), nil, s.getUnaryInfo(), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+
+	buf := &bytes.Buffer{}
+	registry.Write(buf)
+	s.Contains(buf.String(), "turbonetwork_rpc_duration_seconds_count{method=\"/test/Method\"} 1")
+}
+
+func (s *InterceptorsTestSuite) TestUnary_Error() {
+	interceptor := NewUnaryServerInterceptor(Config{Logger: s.logger})
+	_, err := interceptor(context.Background(), nil, s.getUnaryInfo(), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+
+	s.Equal(codes.NotFound, status.Code(err))
+	s.True(strings.Contains(s.buf.String(), "items=0"))
+	s.True(strings.Contains(s.buf.String(), "status=NotFound error=\"rpc error: code = NotFound desc = not found\""))
+}
+
+func (s *InterceptorsTestSuite) TestUnary_ErrorsOnly() {
+	interceptor := NewUnaryServerInterceptor(Config{Logger: s.logger, ErrorsOnly: true})
+	interceptor(context.Background(), nil, s.getUnaryInfo(), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	s.Equal("", s.buf.String())
+
+	interceptor(context.Background(), nil, s.getUnaryInfo(), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+	s.Contains(s.buf.String(), "status=NotFound")
+}
+
+func (s *InterceptorsTestSuite) TestUnary_Guard() {
+	interceptor := NewUnaryServerInterceptor(Config{
+		Logger: s.logger,
+		Guard: func(method string) error {
+			return status.Errorf(codes.Unavailable, "%s is not ready", method)
+		},
+	})
+	handlerCalled := false
+	_, err := interceptor(context.Background(), nil, s.getUnaryInfo(), func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerCalled = true
+		return nil, nil
+	})
+
+	s.False(handlerCalled)
+	s.Equal(codes.Unavailable, status.Code(err))
+	s.Equal("/test/Method is not ready", status.Convert(err).Message())
+	s.Contains(s.buf.String(), "status=Unavailable")
+}
+
+func (s *InterceptorsTestSuite) TestUnary_Panic() {
+	interceptor := NewUnaryServerInterceptor(Config{Logger: s.logger})
+	_, err := interceptor(context.Background(), nil, s.getUnaryInfo(), func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("panic msg")
+	})
+
+	s.Equal(codes.Internal, status.Code(err))
+	s.Equal("panic msg", status.Convert(err).Message())
+	// stack trace is logged but not returned
+	s.True(strings.Contains(s.buf.String(), "panic=\"panic msg\""))
+	s.True(strings.Contains(s.buf.String(), "goroutine"))
+}
+
+func (s *InterceptorsTestSuite) TestStream_Panic() {
+	interceptor := NewStreamServerInterceptor(Config{Logger: s.logger})
+	stream := &streamMock{ctx: context.Background(), msg: &pb.NodeIdentifiers{Ids: make([]*pb.NodeIdentifier, 2)}}
+
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/test/Stream"}, func(srv interface{}, stream grpc.ServerStream) error {
+		s.NotEqual("", GetRequestID(stream.Context()))
+		s.Require().Nil(stream.RecvMsg(&pb.NodeIdentifiers{}))
+		panic(fmt.Errorf("stream panic"))
+	})
+
+	s.Equal(codes.Internal, status.Code(err))
+	s.True(strings.Contains(s.buf.String(), "method=/test/Stream items=2"))
+	s.True(strings.Contains(s.buf.String(), "status=Internal"))
+}
+
+func (s *InterceptorsTestSuite) TestLogf_NoRequest() {
+	// handlers may be called directly without the interceptor and even without context
+	Logf(s.logger, context.Background(), "background")
+	Logf(s.logger, nil, "no context")
+	s.Equal([]string{"request_id= background", "request_id= no context"}, s.getLogLines())
+}
+
+func (s *InterceptorsTestSuite) getUnaryInfo() *grpc.UnaryServerInfo {
+	return &grpc.UnaryServerInfo{FullMethod: "/test/Method"}
+}
+
+func (s *InterceptorsTestSuite) getLogLines() []string {
+	return strings.Split(strings.TrimSpace(s.buf.String()), "\n")
+}
+
+type streamMock struct {
+	grpc.ServerStream
+	ctx context.Context
+	msg *pb.NodeIdentifiers
+}
+
+func (m *streamMock) Context() context.Context {
+	return m.ctx
+}
+
+func (m *streamMock) RecvMsg(msg interface{}) error {
+	*msg.(*pb.NodeIdentifiers) = *m.msg
+	return nil
+}
+
+func TestInterceptorsTestSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorsTestSuite))
+}
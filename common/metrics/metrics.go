@@ -0,0 +1,276 @@
+// Package metrics implements a minimal registry of metrics which are exposed over HTTP
+// in Prometheus text exposition format. All the metrics have a single label.
+// Methods of nil registry and nil metrics do nothing, so that metrics collection can be disabled
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLatencyBuckets are upper bounds (in seconds) of RPC latency histograms
+var DefaultLatencyBuckets = []float64{.001, .005, .01, .05, .1, .5, 1, 5, 10}
+
+// DefaultIterationBuckets are upper bounds of solver iteration count histograms
+var DefaultIterationBuckets = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500}
+
+type collector interface {
+	write(w io.Writer)
+}
+
+// NewRegistry constructs empty Registry
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]collector)}
+}
+
+// Registry keeps metrics and serves them on any path as HTTP handler.
+// Metrics are registered once: registration of the existing name returns the registered metric,
+// so that services sharing the registry can register the same metric independently
+type Registry struct {
+	lock       sync.Mutex
+	collectors []collector
+	names      map[string]collector
+}
+
+// NewCounterVec registers counter with label labelName
+func (r *Registry) NewCounterVec(name, help, labelName string) *CounterVec {
+	if r == nil {
+		return nil
+	}
+	return r.register(name, &CounterVec{
+		desc:   desc{name: name, help: help, labelName: labelName},
+		values: make(map[string]float64),
+	}).(*CounterVec)
+}
+
+// NewHistogramVec registers histogram with label labelName and sorted upper bounds of buckets
+func (r *Registry) NewHistogramVec(name, help, labelName string, buckets []float64) *HistogramVec {
+	if r == nil {
+		return nil
+	}
+	return r.register(name, &HistogramVec{
+		desc:       desc{name: name, help: help, labelName: labelName},
+		buckets:    buckets,
+		histograms: make(map[string]*histogram),
+	}).(*HistogramVec)
+}
+
+// NewGaugeVecFunc registers gauge with label labelName. Values of the gauge
+// are collected by calling f on every scrape
+func (r *Registry) NewGaugeVecFunc(name, help, labelName string, f func() map[string]float64) {
+	if r == nil {
+		return
+	}
+	r.register(name, &gaugeVecFunc{
+		desc: desc{name: name, help: help, labelName: labelName},
+		f:    f,
+	})
+}
+
+// NewRPCLatency registers histogram of RPC durations in seconds by full method name
+func (r *Registry) NewRPCLatency() *HistogramVec {
+	return r.NewHistogramVec(
+		"turbonetwork_rpc_duration_seconds", "Duration of RPC handling", "method", DefaultLatencyBuckets,
+	)
+}
+
+// NewSolverIterations registers histogram of iteration counts of graph solvers by service name.
+// Only the node service reports them: network service has no processor implementation yet
+func (r *Registry) NewSolverIterations() *HistogramVec {
+	return r.NewHistogramVec(
+		"turbonetwork_solver_iterations", "Number of iterations of graph solving", "service", DefaultIterationBuckets,
+	)
+}
+
+// ServeHTTP writes all the metrics in text exposition format
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	r.Write(w)
+}
+
+// Write writes all the metrics in text exposition format
+func (r *Registry) Write(w io.Writer) {
+	r.lock.Lock()
+	collectors := append([]collector{}, r.collectors...)
+	r.lock.Unlock()
+
+	for _, c := range collectors {
+		c.write(w)
+	}
+}
+
+// register saves c under name and returns it. If name is already registered, the registered metric is returned
+func (r *Registry) register(name string, c collector) collector {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if registered, ok := r.names[name]; ok {
+		return registered
+	}
+	r.names[name] = c
+	r.collectors = append(r.collectors, c)
+	return c
+}
+
+// CounterVec is a set of monotonically increasing values distinguished by label value
+type CounterVec struct {
+	desc
+	lock   sync.Mutex
+	values map[string]float64
+}
+
+// Inc increments counter with labelValue
+func (c *CounterVec) Inc(labelValue string) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.values[labelValue]++
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.lock.Lock()
+	values := copyValues(c.values)
+	c.lock.Unlock()
+
+	c.writeHeader(w, "counter")
+	for _, labelValue := range sortedKeys(values) {
+		fmt.Fprintf(w, "%s%s %s\n", c.name, c.labels(labelValue), formatFloat(values[labelValue]))
+	}
+}
+
+// HistogramVec is a set of histograms distinguished by label value
+type HistogramVec struct {
+	desc
+	buckets []float64
+
+	lock       sync.Mutex
+	histograms map[string]*histogram
+}
+
+type histogram struct {
+	counts []uint64 // counts of observations in every bucket (not cumulative)
+	sum    float64
+	count  uint64
+}
+
+// Observe adds val to histogram with labelValue
+func (h *HistogramVec) Observe(labelValue string, val float64) {
+	if h == nil {
+		return
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	hist, ok := h.histograms[labelValue]
+	if !ok {
+		hist = &histogram{counts: make([]uint64, len(h.buckets))}
+		h.histograms[labelValue] = hist
+	}
+	if i := sort.SearchFloat64s(h.buckets, val); i < len(h.buckets) {
+		hist.counts[i]++
+	}
+	hist.sum += val
+	hist.count++
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.lock.Lock()
+	histograms := make(map[string]histogram, len(h.histograms))
+	for labelValue, hist := range h.histograms {
+		histograms[labelValue] = histogram{
+			counts: append([]uint64{}, hist.counts...),
+			sum:    hist.sum,
+			count:  hist.count,
+		}
+	}
+	h.lock.Unlock()
+
+	labelValues := make([]string, 0, len(histograms))
+	for labelValue := range histograms {
+		labelValues = append(labelValues, labelValue)
+	}
+	sort.Strings(labelValues)
+
+	h.writeHeader(w, "histogram")
+	for _, labelValue := range labelValues {
+		hist := histograms[labelValue]
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += hist.counts[i]
+			fmt.Fprintf(
+				w, "%s_bucket%s %d\n", h.name, h.bucketLabels(labelValue, formatFloat(bound)), cumulative,
+			)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, h.bucketLabels(labelValue, "+Inf"), hist.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, h.labels(labelValue), formatFloat(hist.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, h.labels(labelValue), hist.count)
+	}
+}
+
+type gaugeVecFunc struct {
+	desc
+	f func() map[string]float64
+}
+
+func (g *gaugeVecFunc) write(w io.Writer) {
+	values := g.f()
+	g.writeHeader(w, "gauge")
+	for _, labelValue := range sortedKeys(values) {
+		fmt.Fprintf(w, "%s%s %s\n", g.name, g.labels(labelValue), formatFloat(values[labelValue]))
+	}
+}
+
+type desc struct {
+	name      string
+	help      string
+	labelName string
+}
+
+func (d desc) writeHeader(w io.Writer, metricType string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, strings.Replace(d.help, "\n", " ", -1))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, metricType)
+}
+
+func (d desc) labels(labelValue string) string {
+	return fmt.Sprintf("{%s=\"%s\"}", d.labelName, escapeLabelValue(labelValue))
+}
+
+func (d desc) bucketLabels(labelValue string, bound string) string {
+	return fmt.Sprintf("{%s=\"%s\",le=\"%s\"}", d.labelName, escapeLabelValue(labelValue), bound)
+}
+
+func escapeLabelValue(val string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(val)
+}
+
+func formatFloat(val float64) string {
+	if math.IsInf(val, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(val, 'g', -1, 64)
+}
+
+func copyValues(values map[string]float64) map[string]float64 {
+	result := make(map[string]float64, len(values))
+	for key, val := range values {
+		result[key] = val
+	}
+	return result
+}
+
+func sortedKeys(values map[string]float64) []string {
+	result := make([]string, 0, len(values))
+	for key := range values {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegistry_Write(t *testing.T) {
+	registry := NewRegistry()
+
+	counter := registry.NewCounterVec("errors_total", "Errors", "node_type")
+	counter.Inc("b")
+	counter.Inc("a")
+	counter.Inc("b")
+
+	hist := registry.NewHistogramVec("duration_seconds", "Duration", "method", []float64{0.1, 1})
+	hist.Observe("/m", 0.05)
+	hist.Observe("/m", 0.5)
+	hist.Observe("/m", 5)
+
+	registry.NewGaugeVecFunc("nodes", "Nodes", "node_type", func() map[string]float64 {
+		return map[string]float64{"a\"b": 2}
+	})
+
+	buf := &bytes.Buffer{}
+	registry.Write(buf)
+	assert.Equal(t, `# HELP errors_total Errors
+# TYPE errors_total counter
+errors_total{node_type="a"} 1
+errors_total{node_type="b"} 2
+# HELP duration_seconds Duration
+# TYPE duration_seconds histogram
+duration_seconds_bucket{method="/m",le="0.1"} 1
+duration_seconds_bucket{method="/m",le="1"} 2
+duration_seconds_bucket{method="/m",le="+Inf"} 3
+duration_seconds_sum{method="/m"} 5.55
+duration_seconds_count{method="/m"} 3
+# HELP nodes Nodes
+# TYPE nodes gauge
+nodes{node_type="a\"b"} 2
+`, buf.String())
+}
+
+func TestRegistry_RegisterTwice(t *testing.T) {
+	registry := NewRegistry()
+	first := registry.NewSolverIterations()
+	second := registry.NewSolverIterations()
+	assert.True(t, first == second)
+}
+
+func TestRegistry_Nil(t *testing.T) {
+	var registry *Registry
+	counter := registry.NewCounterVec("errors_total", "Errors", "node_type")
+	assert.Nil(t, counter)
+	counter.Inc("a")
+	registry.NewRPCLatency().Observe("/m", 1)
+	registry.NewGaugeVecFunc("nodes", "Nodes", "node_type", nil)
+}
+
+func TestRegistry_ServeHTTP(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewCounterVec("errors_total", "Errors", "node_type").Inc("a")
+
+	recorder := httptest.NewRecorder()
+	registry.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "text/plain; version=0.0.4", recorder.Header().Get("Content-Type"))
+	assert.Contains(t, recorder.Body.String(), `errors_total{node_type="a"} 1`)
+}
@@ -0,0 +1,33 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// GetMultiPortTag returns tag of the i-th port of the multiport with the prefix.
+// Ports of the multiport are numbered from 1: prefix_1, prefix_2 and so on
+func GetMultiPortTag(prefix string, i int) string {
+	return fmt.Sprintf("%s_%d", prefix, i)
+}
+
+// GetMultiPortTags returns tags of all the cnt ports the multiport with the prefix is expanded to
+func GetMultiPortTags(prefix string, cnt int) []string {
+	result := make([]string, cnt)
+	for i := range result {
+		result[i] = GetMultiPortTag(prefix, i+1)
+	}
+	return result
+}
+
+// GetPortPrefix returns prefix of the multiport the port with the tag belongs to.
+// Tags of simple ports are returned as is
+func GetPortPrefix(tag string) string {
+	i := multiPortSuffix.FindStringIndex(tag)
+	if i == nil {
+		return tag
+	}
+	return tag[:i[0]]
+}
+
+var multiPortSuffix = regexp.MustCompile("_[0-9]+$")
@@ -0,0 +1,17 @@
+package common
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetMultiPortTags(t *testing.T) {
+	assert.Equal(t, []string{"power_input_1", "power_input_2"}, GetMultiPortTags("power_input", 2))
+	assert.Equal(t, 0, len(GetMultiPortTags("power_input", 0)))
+}
+
+func TestGetPortPrefix(t *testing.T) {
+	assert.Equal(t, "power_input", GetPortPrefix(GetMultiPortTag("power_input", 12)))
+	assert.Equal(t, "power_input", GetPortPrefix("power_input"))
+	assert.Equal(t, "gas_1_output", GetPortPrefix("gas_1_output"))
+}
@@ -10,6 +10,9 @@ type ObjectStorage interface {
 	Add(key, value interface{}) error
 	Get(key interface{}) (interface{}, error)
 	Drop(key interface{}) error
+	// Range calls f for all the stored objects until f returns false.
+	// Objects added or dropped during iteration may be missed
+	Range(f func(key, value interface{}) bool)
 }
 
 // NewMapObjectStorage constructs ObjectStorage based on synchronized map
@@ -54,3 +57,21 @@ func (s *mapObjectStorage) Drop(key interface{}) error {
 	delete(s.objectMap, key)
 	return nil
 }
+
+func (s *mapObjectStorage) Range(f func(key, value interface{}) bool) {
+	// items are copied so that f can access storage without deadlock
+	s.mapLock.Lock()
+	keys := make([]interface{}, 0, len(s.objectMap))
+	values := make([]interface{}, 0, len(s.objectMap))
+	for key, value := range s.objectMap {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	s.mapLock.Unlock()
+
+	for i := range keys {
+		if !f(keys[i], values[i]) {
+			return
+		}
+	}
+}
@@ -47,6 +47,25 @@ func (s *NodeStorageTestSuite) TestDelete() {
 	s.Equal(1, len(s.storage.objectMap))
 }
 
+func (s *NodeStorageTestSuite) TestRange() {
+	s.storage.Add("key1", "value1")
+	s.storage.Add("key2", "value2")
+
+	items := make(map[interface{}]interface{})
+	s.storage.Range(func(key, value interface{}) bool {
+		items[key] = value
+		return true
+	})
+	s.Equal(map[interface{}]interface{}{"key1": "value1", "key2": "value2"}, items)
+
+	cnt := 0
+	s.storage.Range(func(key, value interface{}) bool {
+		cnt++
+		return false
+	})
+	s.Equal(1, cnt)
+}
+
 func (s *NodeStorageTestSuite) TestGet_OK() {
 	key, value := "key", "value"
 
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	ns "github.com/Sovianum/turbonetwork/nodeservice"
+	"github.com/Sovianum/turbonetwork/nodeservice/adapters"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+// names of the services which can be enabled in config
+const (
+	nodeServiceName    = "node"
+	networkServiceName = "network"
+)
+
+// storage backends of the node service
+const (
+	memoryStorage = "memory"
+	fileStorage   = "file"
+)
+
+// log levels: debug and info log every request, error logs failed requests only
+const (
+	debugLevel = "debug"
+	infoLevel  = "info"
+	errorLevel = "error"
+)
+
+type config struct {
+	ListenAddr string `json:"listenAddr"`
+	// MetricsAddr is an address of HTTP server of /metrics endpoint, metrics are disabled if it is empty
+	MetricsAddr string        `json:"metricsAddr"`
+	Services    []string      `json:"services"`
+	Storage     storageConfig `json:"storage"`
+	Lease       leaseConfig   `json:"lease"`
+	LogLevel    string        `json:"logLevel"`
+	// CompressorMaps are maps compressor nodes may refer to by name. They can be set in config file only
+	CompressorMaps map[string]compressorMapConfig `json:"compressorMaps"`
+}
+
+type storageConfig struct {
+	Backend string `json:"backend"`
+	// Path is a path of the snapshot file of the file backend
+	Path string `json:"path"`
+}
+
+// leaseConfig sets lifetime of node service sessions. Values are Go durations (e.g. "30m").
+// Sessions live until explicit deletion if Duration is empty
+type leaseConfig struct {
+	Duration string `json:"duration"`
+	// ReaperInterval is a period of checks for expired sessions, default one is used if it is empty
+	ReaperInterval string `json:"reaperInterval"`
+}
+
+// compressorMapConfig is a table of compressor efficiency by pressure ratio
+type compressorMapConfig struct {
+	Pi  []float64 `json:"pi"`
+	Eta []float64 `json:"eta"`
+}
+
+func getDefaultConfig() config {
+	return config{
+		ListenAddr:  ":8082",
+		MetricsAddr: ":8083",
+		Services:    []string{nodeServiceName},
+		Storage:     storageConfig{Backend: memoryStorage},
+		LogLevel:    infoLevel,
+	}
+}
+
+// parseConfig builds config out of command line arguments. Values of the config file
+// override defaults and explicitly set flags override values of the config file
+func parseConfig(args []string) (config, error) {
+	flags := flag.NewFlagSet("turbonetwork", flag.ContinueOnError)
+	configPath := flags.String("config", "", "path of JSON config file")
+	listenAddr := flags.String("listen", "", "address of gRPC server")
+	metricsAddr := flags.String("metrics", "", "address of /metrics HTTP endpoint, empty value disables metrics")
+	services := flags.String("services", "", "comma separated list of enabled services (node, network)")
+	storage := flags.String("storage", "", "storage backend of node service (memory, file)")
+	storagePath := flags.String("storage-path", "", "snapshot path of file storage backend")
+	lease := flags.String("lease", "", "lease duration of node service sessions, empty value disables leases")
+	reaperInterval := flags.String("reaper-interval", "", "period of checks for expired sessions")
+	logLevel := flags.String("log-level", "", "log level (debug, info, error)")
+	if err := flags.Parse(args); err != nil {
+		return config{}, err
+	}
+
+	result := getDefaultConfig()
+	if *configPath != "" {
+		data, err := ioutil.ReadFile(*configPath)
+		if err != nil {
+			return config{}, err
+		}
+		if err := json.Unmarshal(data, &result); err != nil {
+			return config{}, fmt.Errorf("failed to parse config %s: %v", *configPath, err)
+		}
+	}
+
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "listen":
+			result.ListenAddr = *listenAddr
+		case "metrics":
+			result.MetricsAddr = *metricsAddr
+		case "services":
+			result.Services = strings.Split(*services, ",")
+		case "storage":
+			result.Storage.Backend = *storage
+		case "storage-path":
+			result.Storage.Path = *storagePath
+		case "lease":
+			result.Lease.Duration = *lease
+		case "reaper-interval":
+			result.Lease.ReaperInterval = *reaperInterval
+		case "log-level":
+			result.LogLevel = *logLevel
+		}
+	})
+
+	return result, result.validate()
+}
+
+func (c config) validate() error {
+	if len(c.Services) == 0 {
+		return fmt.Errorf("no services enabled")
+	}
+	for _, service := range c.Services {
+		if service != nodeServiceName && service != networkServiceName {
+			return fmt.Errorf("unknown service \"%s\"", service)
+		}
+	}
+
+	switch c.Storage.Backend {
+	case memoryStorage:
+	case fileStorage:
+		if c.Storage.Path == "" {
+			return fmt.Errorf("path of file storage is not set")
+		}
+	default:
+		return fmt.Errorf("unknown storage backend \"%s\"", c.Storage.Backend)
+	}
+
+	switch c.LogLevel {
+	case debugLevel, infoLevel, errorLevel:
+	default:
+		return fmt.Errorf("unknown log level \"%s\"", c.LogLevel)
+	}
+
+	if _, err := c.getLeaseConfig(); err != nil {
+		return err
+	}
+
+	_, err := c.getCompressorMaps()
+	return err
+}
+
+// getLeaseConfig returns lease config of the node service or nil if leases are disabled
+func (c config) getLeaseConfig() (*ns.LeaseConfig, error) {
+	if c.Lease.Duration == "" {
+		if c.Lease.ReaperInterval != "" {
+			return nil, fmt.Errorf("reaper interval is set but lease duration is not")
+		}
+		return nil, nil
+	}
+
+	duration, err := parsePositiveDuration(c.Lease.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lease duration: %v", err)
+	}
+	var interval time.Duration
+	if c.Lease.ReaperInterval != "" {
+		if interval, err = parsePositiveDuration(c.Lease.ReaperInterval); err != nil {
+			return nil, fmt.Errorf("invalid reaper interval: %v", err)
+		}
+	}
+	return &ns.LeaseConfig{Duration: duration, ReaperInterval: interval}, nil
+}
+
+func (c config) getCompressorMaps() (map[string]adapters.CompressorMap, error) {
+	result := make(map[string]adapters.CompressorMap, len(c.CompressorMaps))
+	for name, mapConfig := range c.CompressorMaps {
+		m, err := adapters.NewTableCompressorMap(mapConfig.Pi, mapConfig.Eta)
+		if err != nil {
+			return nil, fmt.Errorf("invalid compressor map \"%s\": %v", name, err)
+		}
+		result[name] = m
+	}
+	return result, nil
+}
+
+func parsePositiveDuration(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration %s is not positive", s)
+	}
+	return d, nil
+}
+
+func (c config) hasService(name string) bool {
+	for _, service := range c.Services {
+		if service == name {
+			return true
+		}
+	}
+	return false
+}
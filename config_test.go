@@ -0,0 +1,113 @@
+package main
+
+import (
+	ns "github.com/Sovianum/turbonetwork/nodeservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseConfig_Defaults(t *testing.T) {
+	conf, err := parseConfig(nil)
+	require.Nil(t, err)
+	assert.Equal(t, getDefaultConfig(), conf)
+}
+
+func TestParseConfig_FileAndFlags(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	require.Nil(t, ioutil.WriteFile(path, []byte(`{
+		"listenAddr": ":9000",
+		"metricsAddr": "",
+		"storage": {"backend": "file", "path": "/tmp/snapshot.json"},
+		"logLevel": "error"
+	}`), 0644))
+
+	conf, err := parseConfig([]string{"-config", path, "-listen", ":9001", "-log-level", "debug"})
+	require.Nil(t, err)
+	// flags override config file which overrides defaults
+	assert.Equal(t, ":9001", conf.ListenAddr)
+	assert.Equal(t, "", conf.MetricsAddr)
+	assert.Equal(t, []string{nodeServiceName}, conf.Services)
+	assert.Equal(t, storageConfig{Backend: fileStorage, Path: "/tmp/snapshot.json"}, conf.Storage)
+	assert.Equal(t, debugLevel, conf.LogLevel)
+}
+
+func TestParseConfig_Invalid(t *testing.T) {
+	for _, args := range [][]string{
+		{"-services", "node,unknown"},
+		{"-storage", "file"},
+		{"-storage", "db"},
+		{"-log-level", "verbose"},
+		{"-config", "/nonexistent/config.json"},
+	} {
+		_, err := parseConfig(args)
+		assert.Error(t, err, "%v", args)
+	}
+}
+
+func TestParseConfig_CompressorMaps(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	valid := filepath.Join(dir, "valid.json")
+	require.Nil(t, ioutil.WriteFile(valid, []byte(`{
+		"compressorMaps": {"base": {"pi": [2, 10], "eta": [0.9, 0.8]}}
+	}`), 0644))
+	conf, err := parseConfig([]string{"-config", valid})
+	require.Nil(t, err)
+
+	maps, err := conf.getCompressorMaps()
+	require.Nil(t, err)
+	eta, err := maps["base"].GetEta(6)
+	require.Nil(t, err)
+	assert.InDelta(t, 0.85, eta, 1e-9)
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.Nil(t, ioutil.WriteFile(invalid, []byte(`{
+		"compressorMaps": {"base": {"pi": [2], "eta": [0.9, 0.8]}}
+	}`), 0644))
+	_, err = parseConfig([]string{"-config", invalid})
+	assert.Error(t, err)
+}
+
+func TestParseConfig_Lease(t *testing.T) {
+	conf, err := parseConfig(nil)
+	require.Nil(t, err)
+	lease, err := conf.getLeaseConfig()
+	require.Nil(t, err)
+	assert.Nil(t, lease)
+
+	dir, err := ioutil.TempDir("", "config")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	require.Nil(t, ioutil.WriteFile(path, []byte(`{
+		"lease": {"duration": "30m", "reaperInterval": "1m"}
+	}`), 0644))
+
+	conf, err = parseConfig([]string{"-config", path, "-lease", "1h"})
+	require.Nil(t, err)
+	lease, err = conf.getLeaseConfig()
+	require.Nil(t, err)
+	assert.Equal(t, &ns.LeaseConfig{Duration: time.Hour, ReaperInterval: time.Minute}, lease)
+
+	for _, args := range [][]string{
+		{"-lease", "forever"},
+		{"-lease", "-1m"},
+		{"-lease", "1h", "-reaper-interval", "0s"},
+		{"-reaper-interval", "1m"},
+	} {
+		_, err := parseConfig(args)
+		assert.Error(t, err, "%v", args)
+	}
+}
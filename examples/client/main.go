@@ -0,0 +1,81 @@
+// Command client is an example of the node service client. It creates a pressure loss node
+// on the running server, sets its inlet state, processes it and reads its outlet state
+package main
+
+import (
+	"context"
+	"flag"
+	ns "github.com/Sovianum/turbonetwork/nodeservice"
+	"github.com/Sovianum/turbonetwork/nodeservice/adapters"
+	"github.com/Sovianum/turbonetwork/pb"
+	"google.golang.org/grpc"
+	"log"
+)
+
+var addr = flag.String("addr", "127.0.0.1:8082", "address of the node service")
+
+func main() {
+	flag.Parse()
+
+	conn, clientErr := grpc.Dial(*addr, grpc.WithInsecure())
+	if clientErr != nil {
+		log.Fatal("Failed to connect")
+	}
+
+	client := pb.NewNodeServiceClient(conn)
+
+	createReq, _ := ns.GetCreateRequest([]string{"node"}, []string{adapters.PressureLossNodeType}, []map[string]float64{
+		{"sigma": 0.98},
+	})
+	resp, err := client.CreateNodes(context.Background(), createReq)
+	if err != nil {
+		log.Fatalf("Failed to get response: %s", err.Error())
+	}
+	log.Printf("Succeeded %v", *resp)
+
+	nodeID := resp.Items[0].Identifiers[0]
+	setResp, setErr := client.SetPortsState(context.Background(), &pb.PortUpdateRequest{
+		Items: []*pb.PortUpdateRequest_UnitRequest{
+			getPortUpdateItem(nodeID, "temperature_input", "tStag", 300),
+			getPortUpdateItem(nodeID, "pressure_input", "pStag", 1e5),
+			getPortUpdateItem(nodeID, "mass_rate_input", "massRate", 1),
+			{
+				Identifier: &pb.PortIdentifier{NodeIdentifier: nodeID, PortTag: "gas_input"},
+				State: &pb.PortState{
+					State: &pb.State{StringValues: map[string]string{"gas": "air"}},
+				},
+			},
+		},
+	})
+	if setErr != nil {
+		log.Fatalf("Failed to get response: %s", setErr.Error())
+	}
+	log.Printf("Succeeded %v", *setResp)
+
+	resp1, err1 := client.Process(context.Background(), &pb.NodeIdentifiers{
+		Ids: []*pb.NodeIdentifier{nodeID},
+	})
+	if err1 != nil {
+		log.Fatalf("Failed to get response: %s", err1.Error())
+	}
+	log.Printf("Succeeded %v", *resp1)
+
+	stateResp, stateErr := client.GetPortsState(context.Background(), &pb.PortStateRequest{
+		Items: []*pb.PortStateRequest_UnitRequest{
+			{Identifier: &pb.PortIdentifier{NodeIdentifier: nodeID, PortTag: "pressure_output"}},
+		},
+	})
+	if stateErr != nil {
+		log.Fatalf("Failed to get response: %s", stateErr.Error())
+	}
+	log.Printf("Succeeded %v", *stateResp)
+}
+
+func getPortUpdateItem(nodeID *pb.NodeIdentifier, portTag, field string, val float64) *pb.PortUpdateRequest_UnitRequest {
+	return &pb.PortUpdateRequest_UnitRequest{
+		Identifier: &pb.PortIdentifier{NodeIdentifier: nodeID, PortTag: portTag},
+		State: &pb.PortState{
+			State: &pb.State{NumValues: map[string]float64{field: val}},
+		},
+	}
+}
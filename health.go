@@ -0,0 +1,87 @@
+package main
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"strings"
+	"sync"
+)
+
+// full names of the gRPC services by the names used in config
+var grpcServiceNames = map[string]string{
+	nodeServiceName:    "nodeservice.NodeService",
+	networkServiceName: "networkservice.NetworkService",
+}
+
+// readiness tracks loading of the enabled services and reports it via standard health service.
+// Service is NOT_SERVING until it is marked ready, requests to it are rejected with UNAVAILABLE.
+// Overall server status (empty service name) is SERVING when all the enabled services are ready
+type readiness struct {
+	health *health.Server
+
+	mu    sync.RWMutex
+	ready map[string]bool
+}
+
+// newReadiness constructs readiness with all the known services NOT_SERVING.
+// Services which are not enabled stay NOT_SERVING forever
+func newReadiness(services []string) *readiness {
+	r := &readiness{
+		health: health.NewServer(),
+		ready:  make(map[string]bool, len(services)),
+	}
+	r.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	for _, name := range grpcServiceNames {
+		r.health.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	for _, service := range services {
+		r.ready[grpcServiceNames[service]] = false
+	}
+	return r
+}
+
+// setReady marks service (config name) as SERVING and accepts its requests
+func (r *readiness) setReady(service string) {
+	name := grpcServiceNames[service]
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ready[name] = true
+	r.health.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+
+	for _, ready := range r.ready {
+		if !ready {
+			return
+		}
+	}
+	r.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+}
+
+// check rejects requests to the services which are not ready yet.
+// Methods of the other services (health, reflection) are always accepted
+func (r *readiness) check(method string) error {
+	name := getServiceName(method)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if ready, ok := r.ready[name]; ok && !ready {
+		return status.Errorf(codes.Unavailable, "service %s is not ready yet", name)
+	}
+	return nil
+}
+
+// shutdown sets all the services NOT_SERVING and ignores further status updates
+func (r *readiness) shutdown() {
+	r.health.Shutdown()
+}
+
+// getServiceName extracts service name from full method name of the form /service/method
+func getServiceName(method string) string {
+	method = strings.TrimPrefix(method, "/")
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		return method[:i]
+	}
+	return method
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+const nodeServiceMethod = "/nodeservice.NodeService/CreateNodes"
+
+func TestReadiness_Loading(t *testing.T) {
+	r := newReadiness([]string{nodeServiceName})
+
+	assertHealth(t, r, "", healthpb.HealthCheckResponse_NOT_SERVING)
+	assertHealth(t, r, "nodeservice.NodeService", healthpb.HealthCheckResponse_NOT_SERVING)
+	assertHealth(t, r, "networkservice.NetworkService", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	err := r.check(nodeServiceMethod)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Nil(t, r.check("/grpc.health.v1.Health/Check"))
+}
+
+func TestReadiness_Ready(t *testing.T) {
+	r := newReadiness([]string{nodeServiceName})
+	r.setReady(nodeServiceName)
+
+	assertHealth(t, r, "", healthpb.HealthCheckResponse_SERVING)
+	assertHealth(t, r, "nodeservice.NodeService", healthpb.HealthCheckResponse_SERVING)
+	// network service is not enabled
+	assertHealth(t, r, "networkservice.NetworkService", healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.Nil(t, r.check(nodeServiceMethod))
+}
+
+func TestReadiness_PartiallyReady(t *testing.T) {
+	r := newReadiness([]string{nodeServiceName, networkServiceName})
+	r.setReady(nodeServiceName)
+
+	assertHealth(t, r, "", healthpb.HealthCheckResponse_NOT_SERVING)
+	assertHealth(t, r, "nodeservice.NodeService", healthpb.HealthCheckResponse_SERVING)
+	assert.Nil(t, r.check(nodeServiceMethod))
+	assert.Equal(t, codes.Unavailable, status.Code(r.check("/networkservice.NetworkService/Process")))
+}
+
+func TestReadiness_Shutdown(t *testing.T) {
+	r := newReadiness([]string{nodeServiceName})
+	r.setReady(nodeServiceName)
+	r.shutdown()
+
+	assertHealth(t, r, "", healthpb.HealthCheckResponse_NOT_SERVING)
+	assertHealth(t, r, "nodeservice.NodeService", healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestGetServiceName(t *testing.T) {
+	assert.Equal(t, "nodeservice.NodeService", getServiceName(nodeServiceMethod))
+	assert.Equal(t, "grpc.reflection.v1alpha.ServerReflection", getServiceName(
+		"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+	))
+}
+
+func assertHealth(t *testing.T, r *readiness, service string, expected healthpb.HealthCheckResponse_ServingStatus) {
+	resp, err := r.health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.Nil(t, err)
+	assert.Equal(t, expected, resp.Status, service)
+}
@@ -1,51 +1,180 @@
+// Command turbonetwork runs gRPC server of the turbonetwork services.
+// See parseConfig for the available flags and config file format
 package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"github.com/Sovianum/turbonetwork/common/interceptors"
+	"github.com/Sovianum/turbonetwork/common/metrics"
 	ns "github.com/Sovianum/turbonetwork/nodeservice"
 	"github.com/Sovianum/turbonetwork/nodeservice/adapters"
 	"github.com/Sovianum/turbonetwork/pb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/grpclog"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 )
 
-var port = 8082
-
 func main() {
-	lis, serverErr := net.Listen("tcp", fmt.Sprintf(":%d", port))
-	if serverErr != nil {
-		log.Fatalf("failed to listen: %v", serverErr)
+	conf, err := parseConfig(os.Args[1:])
+	if err == flag.ErrHelp {
+		return
+	}
+	if err != nil {
+		log.Fatalf("invalid config: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
-	gteServer := ns.NewGTEServer(nil)
+	if err := run(conf); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run serves requests until SIGTERM or SIGINT is received. Server starts before the services
+// are loaded, so health service reports NOT_SERVING while storage is restoring.
+// Server stops gracefully: new requests are rejected and the running ones are waited for
+func run(conf config) error {
+	logger := log.New(os.Stderr, "", log.LstdFlags)
+	if conf.LogLevel == debugLevel {
+		grpclog.SetLoggerV2(grpclog.NewLoggerV2(os.Stderr, os.Stderr, os.Stderr))
+	}
 
-	pb.RegisterNodeServiceServer(grpcServer, gteServer)
-	go grpcServer.Serve(lis)
+	// background jobs of the services run until the server is stopped
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	conn, clientErr := grpc.Dial(fmt.Sprintf("127.0.0.1:%d", port), grpc.WithInsecure())
-	if clientErr != nil {
-		log.Fatal("Failed to connect")
+	var registry *metrics.Registry
+	if conf.MetricsAddr != "" {
+		registry = metrics.NewRegistry()
 	}
 
-	client := pb.NewNodeServiceClient(conn)
+	ready := newReadiness(conf.Services)
+	grpcServer := grpc.NewServer(interceptors.ServerOptions(interceptors.Config{
+		Logger:     logger,
+		ErrorsOnly: conf.LogLevel == errorLevel,
+		Registry:   registry,
+		Guard:      ready.check,
+	})...)
+	healthpb.RegisterHealthServer(grpcServer, ready.health)
+	reflection.Register(grpcServer)
 
-	createReq, _ := ns.GetCreateRequest([]string{"node"}, []string{adapters.PressureLossNodeType}, []map[string]float64{
-		{"sigma": 1},
-	})
-	resp, err := client.CreateNodes(context.Background(), createReq)
+	load, err := registerServices(ctx, grpcServer, conf, registry, logger, ready)
 	if err != nil {
-		log.Fatalf("Failed to get response: %s", err.Error())
+		return err
 	}
-	log.Printf("Succeeded %v", *resp)
 
-	resp1, err1 := client.Process(context.Background(), &pb.NodeIdentifiers{
-		Ids: []*pb.NodeIdentifier{resp.Items[0].Identifiers[0]},
-	})
-	if err1 != nil {
-		log.Fatalf("Failed to get response: %s", err1.Error())
+	lis, err := net.Listen("tcp", conf.ListenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+
+	var metricsServer *http.Server
+	if registry != nil {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", registry)
+		metricsServer = &http.Server{Addr: conf.MetricsAddr, Handler: mux}
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Printf("metrics server failed: %v", err)
+			}
+		}()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		sig := <-signals
+		logger.Printf("received %v, stopping server", sig)
+		ready.shutdown()
+		grpcServer.GracefulStop()
+	}()
+
+	loadErrs := make(chan error, 1)
+	go func() {
+		if err := load(); err != nil {
+			loadErrs <- err
+			ready.shutdown()
+			grpcServer.Stop()
+			return
+		}
+		logger.Printf("services are ready")
+	}()
+
+	logger.Printf("serving %s on %s", strings.Join(conf.Services, ", "), conf.ListenAddr)
+	serveErr := grpcServer.Serve(lis)
+	if metricsServer != nil {
+		metricsServer.Close()
+	}
+	select {
+	case err := <-loadErrs:
+		return err
+	default:
+		return serveErr
+	}
+}
+
+// registerServices registers placeholders of the enabled services, so that the server can be started
+// before the services are loaded. Returned function loads the services and marks them ready
+func registerServices(
+	ctx context.Context, grpcServer *grpc.Server, conf config,
+	registry *metrics.Registry, logger *log.Logger, ready *readiness,
+) (func() error, error) {
+	if conf.hasService(networkServiceName) {
+		return nil, fmt.Errorf("network service has no server implementation yet")
+	}
+
+	nodeService := &pendingNodeService{}
+	if conf.hasService(nodeServiceName) {
+		pb.RegisterNodeServiceServer(grpcServer, nodeService)
+	}
+
+	return func() error {
+		if !conf.hasService(nodeServiceName) {
+			return nil
+		}
+		maps, err := conf.getCompressorMaps()
+		if err != nil {
+			return err
+		}
+		lease, err := conf.getLeaseConfig()
+		if err != nil {
+			return err
+		}
+		factory := adapters.NewConfiguredNodeAdapterRegistry(adapters.RegistryConfig{CompressorMaps: maps})
+		storage, err := getNodeStorage(conf.Storage, factory)
+		if err != nil {
+			return err
+		}
+
+		nodeService.NodeServiceServer = ns.NewConfiguredGTEServer(ctx, ns.GTEServerConfig{
+			Factory: factory,
+			Storage: storage,
+			Lease:   lease,
+			Metrics: registry,
+			Logger:  logger,
+		})
+		ready.setReady(nodeServiceName)
+		return nil
+	}, nil
+}
+
+// pendingNodeService is registered before the node service is loaded. Readiness check
+// rejects its requests until the loaded server is assigned
+type pendingNodeService struct {
+	pb.NodeServiceServer
+}
+
+func getNodeStorage(conf storageConfig, factory adapters.NodeAdapterFactory) (ns.NodeStorage, error) {
+	if conf.Backend == fileStorage {
+		return ns.NewFileNodeStorage(conf.Path, factory)
 	}
-	log.Printf("Succeeded %v", *resp1)
+	return ns.NewMapNodeStorage(), nil
 }
@@ -37,6 +37,10 @@ type contextSelector struct {
 }
 
 func (cs *contextSelector) configure() error {
+	if err := cs.checkDanglingPorts(); err != nil {
+		return err
+	}
+
 	validConfigurations := cs.findValidConfigurations()
 	if l := len(validConfigurations); l == 0 {
 		return fmt.Errorf("valid configs not found")
@@ -53,6 +57,37 @@ func (cs *contextSelector) configure() error {
 	return nil
 }
 
+// checkDanglingPorts checks that ports of non-terminal nodes are linked to ports of the graph.
+// Such unlinked ports stay neutral in connection matrix and hide invalid configurations.
+// Ports of terminal nodes (sources and sinks) are allowed to stay unlinked
+func (cs *contextSelector) checkDanglingPorts() error {
+	var errList []error
+	for _, node := range cs.nodes {
+		if node.IsTerminal() {
+			continue
+		}
+		for _, port := range node.GetPorts() {
+			linkPort := port.GetLinkPort()
+			if linkPort == nil {
+				errList = append(errList, fmt.Errorf(
+					"port %s of node %s is not linked", port.GetTag(), node.GetName(),
+				))
+				continue
+			}
+			if _, ok := cs.portIndex[linkPort]; !ok {
+				errList = append(errList, fmt.Errorf(
+					"port %s of node %s is linked to port outside of the graph", port.GetTag(), node.GetName(),
+				))
+			}
+		}
+	}
+
+	if errList == nil {
+		return nil
+	}
+	return fmt.Errorf("dangling ports found: %s", joinErrors(errList))
+}
+
 func (cs *contextSelector) findValidConfigurations() [][]int {
 	limits := make([]int, len(cs.connConfigs))
 	for i, c := range cs.connConfigs {
@@ -84,6 +119,10 @@ func (cs *contextSelector) checkGraphMatrix(variant []map[graph.Port]connType) b
 func (cs *contextSelector) updateConnMatrix(connLine map[graph.Port]connType) {
 	for from, connType := range connLine {
 		to := from.GetLinkPort()
+		if _, ok := cs.portIndex[to]; !ok {
+			// dangling ports are reported by checkDanglingPorts
+			continue
+		}
 		cs.connMatrix.Set(
 			int(connType), cs.portIndex[from], cs.portIndex[to],
 		)
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/Sovianum/turbocycle/core/graph"
 	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"testing"
 )
@@ -284,3 +286,160 @@ func mustExtract(node RepresentationNode, portTag string) graph.Port {
 	}
 	return port
 }
+
+func TestContextSelector_Turbine(t *testing.T) {
+	tc := []struct {
+		name          string
+		powerConsumer *pb.NodeDescription
+		powerTag      string
+		powerType     connType
+	}{
+		{
+			name:          "blocked",
+			powerConsumer: getCompressorDescription(),
+			powerTag:      "power_output",
+			powerType:     pb.NodeDescription_AttachedPortDescription_INPUT,
+		},
+		{
+			name:          "free",
+			powerConsumer: getSinkDescription(),
+			powerTag:      inputTag,
+			powerType:     pb.NodeDescription_AttachedPortDescription_OUTPUT,
+		},
+	}
+
+	for _, c := range tc {
+		turbine, err := NewRepresentationNode(getTurbineDescription(), nil)
+		require.Nil(t, err, c.name)
+		consumer, err := NewRepresentationNode(c.powerConsumer, nil)
+		require.Nil(t, err, c.name)
+
+		nodes := []RepresentationNode{turbine, consumer}
+		nodes = append(nodes, attachGasTerminals(turbine)...)
+		if !consumer.IsTerminal() {
+			nodes = append(nodes, attachGasTerminals(consumer)...)
+		}
+		mustLink(turbine, consumer, "power", c.powerTag)
+
+		selector := newContextSelector(nodes)
+		require.Nil(t, selector.configure(), c.name)
+
+		powerPort := mustExtract(turbine, "power")
+		requirePorts, _ := turbine.GetRequirePorts()
+		updatePorts, _ := turbine.GetUpdatePorts()
+
+		var ports []graph.Port
+		if c.powerType == pb.NodeDescription_AttachedPortDescription_INPUT {
+			ports = requirePorts
+		} else {
+			ports = updatePorts
+		}
+		assert.Contains(t, ports, powerPort, c.name)
+	}
+}
+
+func TestContextSelector_BoundaryNodes(t *testing.T) {
+	inlet, err := NewRepresentationNode(getGasDescription("inlet", false, true), nil)
+	require.Nil(t, err)
+	outlet, err := NewRepresentationNode(getGasDescription("outlet", true, false), nil)
+	require.Nil(t, err)
+	pressureLoss, err := NewRepresentationNode(getGasDescription("pressureLoss", true, true), nil)
+	require.Nil(t, err)
+
+	for _, kind := range gasPortKinds {
+		mustLink(inlet, pressureLoss, kind+"_output", kind+"_input")
+	}
+
+	// outputs of non-terminal node must be linked
+	selector := newContextSelector([]RepresentationNode{inlet, pressureLoss, outlet})
+	err = selector.configure()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dangling ports found")
+	assert.Contains(t, err.Error(), "port gas_output")
+	assert.NotContains(t, err.Error(), "port gas_input")
+
+	for _, kind := range gasPortKinds {
+		mustLink(pressureLoss, outlet, kind+"_output", kind+"_input")
+	}
+
+	selector = newContextSelector([]RepresentationNode{inlet, pressureLoss, outlet})
+	require.Nil(t, selector.configure())
+}
+
+func TestContextSelector_UnlinkedTerminalPorts(t *testing.T) {
+	inlet, _ := NewRepresentationNode(getGasDescription("inlet", false, true), nil)
+	outlet, _ := NewRepresentationNode(getGasDescription("outlet", true, false), nil)
+	source, _ := NewRepresentationNode(getSourceDescription(), nil)
+
+	mustLink(inlet, outlet, "gas_output", "gas_input")
+
+	selector := newContextSelector([]RepresentationNode{inlet, outlet, source})
+	require.Nil(t, selector.configure())
+}
+
+var gasPortKinds = []string{"temperature", "pressure", "mass_rate", "gas"}
+
+// getGasDescription returns description of node with gas path inputs and (or) outputs
+// of every port kind of gasPortKinds
+func getGasDescription(
+	nodeType string, inputs, outputs bool, extraPorts ...*pb.NodeDescription_AttachedPortDescription,
+) *pb.NodeDescription {
+	var ports []*pb.NodeDescription_AttachedPortDescription
+	for _, kind := range gasPortKinds {
+		if inputs {
+			ports = append(ports, getAttachedPortDescription(kind+"_input", pb.NodeDescription_AttachedPortDescription_INPUT))
+		}
+		if outputs {
+			ports = append(ports, getAttachedPortDescription(kind+"_output", pb.NodeDescription_AttachedPortDescription_OUTPUT))
+		}
+	}
+	return &pb.NodeDescription{
+		NodeType:  nodeType,
+		BasePorts: append(ports, extraPorts...),
+	}
+}
+
+func getCompressorDescription() *pb.NodeDescription {
+	return getGasDescription(
+		"compressor", true, true,
+		getAttachedPortDescription("power_output", pb.NodeDescription_AttachedPortDescription_OUTPUT),
+	)
+}
+
+// getTurbineDescription returns description of turbine which either drives compressor
+// (power is an input) or free load (power is an output)
+func getTurbineDescription() *pb.NodeDescription {
+	result := getGasDescription(
+		"turbine", true, true,
+		getAttachedPortDescription("power", pb.NodeDescription_AttachedPortDescription_CONTEXT_DEPENDENT),
+	)
+	result.ContextStates = []*pb.NodeDescription_ContextState{
+		{Ports: []*pb.NodeDescription_AttachedPortDescription{
+			getAttachedPortDescription("power", pb.NodeDescription_AttachedPortDescription_INPUT),
+		}},
+		{Ports: []*pb.NodeDescription_AttachedPortDescription{
+			getAttachedPortDescription("power", pb.NodeDescription_AttachedPortDescription_OUTPUT),
+		}},
+	}
+	return result
+}
+
+func getAttachedPortDescription(prefix string, portType connType) *pb.NodeDescription_AttachedPortDescription {
+	return &pb.NodeDescription_AttachedPortDescription{
+		Type:        portType,
+		Description: &pb.PortDescription{Prefix: prefix},
+	}
+}
+
+// attachGasTerminals links gas inputs of node with new inlet and
+// gas outputs of node with new outlet and returns created nodes
+func attachGasTerminals(node RepresentationNode) []RepresentationNode {
+	inlet, _ := NewRepresentationNode(getGasDescription("inlet", false, true), nil)
+	outlet, _ := NewRepresentationNode(getGasDescription("outlet", true, false), nil)
+
+	for _, kind := range gasPortKinds {
+		mustLink(inlet, node, kind+"_output", kind+"_input")
+		mustLink(node, outlet, kind+"_output", kind+"_input")
+	}
+	return []RepresentationNode{inlet, outlet}
+}
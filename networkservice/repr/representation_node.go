@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"github.com/Sovianum/turbocycle/common"
 	"github.com/Sovianum/turbocycle/core/graph"
+	netcommon "github.com/Sovianum/turbonetwork/common"
 	"github.com/Sovianum/turbonetwork/pb"
-	"regexp"
 )
 
 type connType = pb.NodeDescription_AttachedPortDescription_PortType
@@ -22,6 +22,9 @@ type RepresentationNode interface {
 	GetPortByName(portTag string) (graph.Port, error)
 	GetConnectionLines() []map[graph.Port]connType
 	SelectState(stateID int) error
+	// IsTerminal tells if the node is a source or a sink of the graph:
+	// all its ports are either inputs or outputs. Terminal ports may stay unlinked
+	IsTerminal() bool
 }
 
 // NewRepresentationNode constructs RepresentationNode by its description and map of its multiports
@@ -31,42 +34,35 @@ func NewRepresentationNode(description *pb.NodeDescription, multiPortMap map[str
 		return nil, err
 	}
 
-	multiPortMapCopy := make(map[string]int)
-	for key, val := range multiPortMap {
-		multiPortMapCopy[key] = val
-	}
-
 	result := &representationNode{
 		description:      description,
-		ports:            make([]graph.Port, len(description.BasePorts)),
+		ports:            make([]graph.Port, 0, len(description.BasePorts)),
 		requirePorts:     make([]graph.Port, 0),
 		updatePorts:      make([]graph.Port, 0),
 		portIndex:        make(map[string]int),
 		descriptionIndex: make(map[graph.Port]portDescription),
+		isTerminal:       isTerminalDescription(description),
 	}
 
-	for i, basePortDescription := range description.BasePorts {
-		port := graph.NewAttachedPort(result)
-		result.ports[i] = port
-
-		var portTag string
+	for _, basePortDescription := range description.BasePorts {
 		prefix := basePortDescription.Description.Prefix
-		if !basePortDescription.Description.IsMulti {
-			portTag = prefix
-		} else {
-			cnt := multiPortMapCopy[prefix]
-			portTag = fmt.Sprintf("%s_%d", prefix, cnt)
-			multiPortMapCopy[prefix]--
+		portTags := []string{prefix}
+		if basePortDescription.Description.IsMulti {
+			portTags = netcommon.GetMultiPortTags(prefix, multiPortMap[prefix])
 		}
 
-		port.SetTag(portTag)
-		result.portIndex[portTag] = i
-
-		switch basePortDescription.Type {
-		case pb.NodeDescription_AttachedPortDescription_INPUT:
-			result.requirePorts = append(result.requirePorts, port)
-		case pb.NodeDescription_AttachedPortDescription_OUTPUT:
-			result.updatePorts = append(result.updatePorts, port)
+		for _, portTag := range portTags {
+			port := graph.NewAttachedPort(result)
+			port.SetTag(portTag)
+			result.portIndex[portTag] = len(result.ports)
+			result.ports = append(result.ports, port)
+
+			switch basePortDescription.Type {
+			case pb.NodeDescription_AttachedPortDescription_INPUT:
+				result.requirePorts = append(result.requirePorts, port)
+			case pb.NodeDescription_AttachedPortDescription_OUTPUT:
+				result.updatePorts = append(result.updatePorts, port)
+			}
 		}
 	}
 
@@ -87,6 +83,7 @@ type representationNode struct {
 	descriptionIndex map[graph.Port]portDescription
 	requirePorts     []graph.Port
 	updatePorts      []graph.Port
+	isTerminal       bool
 }
 
 func (node *representationNode) GetName() string {
@@ -117,6 +114,10 @@ func (node *representationNode) ContextDefined(key int) bool {
 	return true
 }
 
+func (node *representationNode) IsTerminal() bool {
+	return node.isTerminal
+}
+
 func (node *representationNode) GetConnectionLines() []map[graph.Port]connType {
 	var resultLength int
 	if cl := len(node.description.ContextStates); cl == 0 {
@@ -180,9 +181,27 @@ func (node *representationNode) getPortByName(portTag string) (graph.Port, error
 	return node.ports[index], nil
 }
 
+// isTerminalDescription checks that all the ports of description have the same direction
+func isTerminalDescription(description *pb.NodeDescription) bool {
+	if len(description.BasePorts) == 0 {
+		return false
+	}
+	portType := description.BasePorts[0].Type
+	if portType != pb.NodeDescription_AttachedPortDescription_INPUT &&
+		portType != pb.NodeDescription_AttachedPortDescription_OUTPUT {
+		return false
+	}
+	for _, pd := range description.BasePorts {
+		if pd.Type != portType {
+			return false
+		}
+	}
+	return true
+}
+
 func getPortDescription(portName string, nodeDescription *pb.NodeDescription) portDescription {
 	result := portDescription{}
-	prefix := getPrefix(portName)
+	prefix := netcommon.GetPortPrefix(portName)
 	var portType pb.NodeDescription_AttachedPortDescription_PortType
 
 	for i, bd := range nodeDescription.BasePorts {
@@ -226,6 +245,7 @@ func checkArgs(description *pb.NodeDescription, multiPortMap map[string]int) err
 			))
 			continue
 		}
+		seen[prefix] = true
 
 		//check that there is no multi context defined ports
 		if contextDependent && isMulti {
@@ -244,6 +264,15 @@ func checkArgs(description *pb.NodeDescription, multiPortMap map[string]int) err
 			continue
 		}
 
+		// check that multi port has at least one port
+		if cnt := multiPortMap[prefix]; isMulti && cnt <= 0 {
+			errList = append(errList, fmt.Errorf(
+				"port number of multi port \"%s\" of node \"%s\" must be positive (got %d)",
+				prefix, description.NodeType, cnt,
+			))
+			continue
+		}
+
 		if contextDependent {
 			contextDependentTags[prefix] = true
 		}
@@ -328,13 +357,3 @@ func joinErrors(errors []error) string {
 	result += "]"
 	return result
 }
-
-func getPrefix(s string) string {
-	i := prefixMatcher.Find([]byte(s))
-	if i == nil {
-		return s
-	}
-	return s[:i[0]]
-}
-
-var prefixMatcher = regexp.MustCompile("_[0-9]+$")
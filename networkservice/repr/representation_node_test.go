@@ -105,6 +105,69 @@ func TestRepresentationNode_GetConnectionLines(t *testing.T) {
 	assert.Equal(t, 3, len(lines[2]))
 }
 
+func TestRepresentationNode_IsTerminal(t *testing.T) {
+	tc := []struct {
+		description *pb.NodeDescription
+		isTerminal  bool
+	}{
+		{getSourceDescription(), true},
+		{getSinkDescription(), true},
+		{getBipoleDescription(), false},
+		{get1In2Out(), false},
+		{&pb.NodeDescription{NodeType: "empty"}, false},
+	}
+
+	for _, c := range tc {
+		node, err := NewRepresentationNode(c.description, nil)
+		assert.Nil(t, err, c.description.NodeType)
+		assert.Equal(t, c.isTerminal, node.IsTerminal(), c.description.NodeType)
+	}
+}
+
+func TestRepresentationNode_MultiPort(t *testing.T) {
+	description := &pb.NodeDescription{
+		NodeType: "multiNode",
+		BasePorts: []*pb.NodeDescription_AttachedPortDescription{
+			{
+				Type:        pb.NodeDescription_AttachedPortDescription_INPUT,
+				Description: &pb.PortDescription{Prefix: inputTag, IsMulti: true},
+			},
+			{
+				Type:        pb.NodeDescription_AttachedPortDescription_OUTPUT,
+				Description: &pb.PortDescription{Prefix: outputTag},
+			},
+		},
+	}
+
+	node, err := NewRepresentationNode(description, map[string]int{inputTag: 3})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(node.GetPorts()))
+
+	requirePorts, _ := node.GetRequirePorts()
+	assert.Equal(t, 3, len(requirePorts))
+	for i, tag := range []string{inputTag + "_1", inputTag + "_2", inputTag + "_3"} {
+		port, err := node.GetPortByName(tag)
+		assert.Nil(t, err, tag)
+		assert.Equal(t, requirePorts[i], port, tag)
+		assert.Equal(t, tag, port.GetTag())
+	}
+	_, err = node.GetPortByName(inputTag + "_4")
+	assert.Error(t, err)
+
+	lines := node.GetConnectionLines()
+	assert.Equal(t, 1, len(lines))
+	assert.Equal(t, 4, len(lines[0]))
+	for _, port := range requirePorts {
+		assert.Equal(t, pb.NodeDescription_AttachedPortDescription_INPUT, lines[0][port])
+	}
+
+	_, err = NewRepresentationNode(description, nil)
+	assert.Error(t, err)
+
+	_, err = NewRepresentationNode(description, map[string]int{inputTag: 0})
+	assert.Error(t, err)
+}
+
 func TestRepresentationNode_SelectState_Single(t *testing.T) {
 	//nodeTmp, _ := NewRepresentationNode(get2In1Out(), nil)
 	//node := nodeTmp.(*representationNode)
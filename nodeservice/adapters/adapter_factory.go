@@ -3,4 +3,6 @@ package adapters
 // NodeAdapterFactory returns NodeAdapter by type name of the node it can handle
 type NodeAdapterFactory interface {
 	GetAdapter(nodeType string) (NodeAdapter, error)
+	// GetNodeTypes returns sorted list of node types which factory can handle
+	GetNodeTypes() []string
 }
@@ -0,0 +1,102 @@
+package adapters
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// RegistryConfig contains optional data of the builtin adapters
+type RegistryConfig struct {
+	// CompressorMaps are maps compressor nodes may refer to by name, no maps are available if it is nil
+	CompressorMaps map[string]CompressorMap
+}
+
+// getBuiltinAdapters returns adapters of all the node types provided by the package
+func getBuiltinAdapters(config RegistryConfig) map[string]NodeAdapter {
+	return map[string]NodeAdapter{
+		PressureLossNodeType: NewPressureLossAdapter(),
+		CompressorNodeType:   NewCompressorAdapter(config.CompressorMaps),
+		TurbineNodeType:      NewTurbineAdapter(),
+		BurnerNodeType:       NewBurnerAdapter(),
+		InletNodeType:        NewInletAdapter(),
+		OutletNodeType:       NewOutletAdapter(),
+		ShaftNodeType:        NewShaftAdapter(),
+		RegeneratorNodeType:  NewRegeneratorAdapter(),
+	}
+}
+
+// NodeAdapterRegistry is a NodeAdapterFactory which allows to register adapters at runtime
+type NodeAdapterRegistry interface {
+	NodeAdapterFactory
+	// Register saves adapter under node type. Only one adapter per node type is allowed
+	Register(nodeType string, adapter NodeAdapter) error
+}
+
+// NewNodeAdapterRegistry constructs empty NodeAdapterRegistry
+func NewNodeAdapterRegistry() NodeAdapterRegistry {
+	return &mapAdapterRegistry{
+		mapLock:    sync.RWMutex{},
+		adapterMap: make(map[string]NodeAdapter),
+	}
+}
+
+// NewDefaultNodeAdapterRegistry constructs NodeAdapterRegistry with all adapters provided by the package
+func NewDefaultNodeAdapterRegistry() NodeAdapterRegistry {
+	return NewConfiguredNodeAdapterRegistry(RegistryConfig{})
+}
+
+// NewConfiguredNodeAdapterRegistry constructs NodeAdapterRegistry with all adapters provided by the package
+// set up with config. It panics if builtin adapters can not be registered, which means the package itself is broken
+func NewConfiguredNodeAdapterRegistry(config RegistryConfig) NodeAdapterRegistry {
+	result := NewNodeAdapterRegistry()
+	for nodeType, adapter := range getBuiltinAdapters(config) {
+		if err := result.Register(nodeType, adapter); err != nil {
+			panic(fmt.Sprintf("failed to register builtin adapter: %v", err))
+		}
+	}
+	return result
+}
+
+type mapAdapterRegistry struct {
+	mapLock    sync.RWMutex
+	adapterMap map[string]NodeAdapter
+}
+
+func (r *mapAdapterRegistry) Register(nodeType string, adapter NodeAdapter) error {
+	if adapter == nil {
+		return fmt.Errorf("nil adapter for node type %s", nodeType)
+	}
+
+	r.mapLock.Lock()
+	defer r.mapLock.Unlock()
+
+	if _, ok := r.adapterMap[nodeType]; ok {
+		return fmt.Errorf("adapter for node type %s already registered", nodeType)
+	}
+	r.adapterMap[nodeType] = adapter
+	return nil
+}
+
+func (r *mapAdapterRegistry) GetAdapter(nodeType string) (NodeAdapter, error) {
+	r.mapLock.RLock()
+	defer r.mapLock.RUnlock()
+
+	adapter, ok := r.adapterMap[nodeType]
+	if !ok {
+		return nil, fmt.Errorf("adapter for node type %s not found", nodeType)
+	}
+	return adapter, nil
+}
+
+func (r *mapAdapterRegistry) GetNodeTypes() []string {
+	r.mapLock.RLock()
+	defer r.mapLock.RUnlock()
+
+	result := make([]string, 0, len(r.adapterMap))
+	for nodeType := range r.adapterMap {
+		result = append(result, nodeType)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,89 @@
+package adapters
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type testAdapter struct{}
+
+func (testAdapter) Create(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+	return nil, nil
+}
+func (testAdapter) Update(node graph.Node, data *pb.RequestData) error { return nil }
+func (testAdapter) GetState(node graph.Node, requiredFields []string) (*pb.NodeState, error) {
+	return nil, nil
+}
+func (testAdapter) GetPort(tag string, node graph.Node) (graph.Port, error) { return nil, nil }
+func (testAdapter) GetDescription() *pb.NodeDescription                     { return nil }
+
+type AdapterRegistryTestSuite struct {
+	suite.Suite
+	registry NodeAdapterRegistry
+}
+
+func (s *AdapterRegistryTestSuite) SetupTest() {
+	s.registry = NewNodeAdapterRegistry()
+}
+
+func (s *AdapterRegistryTestSuite) TestRegister_OK() {
+	err := s.registry.Register("a", testAdapter{})
+	s.Require().Nil(err)
+
+	adapter, err := s.registry.GetAdapter("a")
+	s.Require().Nil(err)
+	s.Equal(testAdapter{}, adapter)
+}
+
+func (s *AdapterRegistryTestSuite) TestRegister_Duplicate() {
+	s.Require().Nil(s.registry.Register("a", testAdapter{}))
+	s.Require().Error(s.registry.Register("a", testAdapter{}))
+}
+
+func (s *AdapterRegistryTestSuite) TestRegister_Nil() {
+	s.Require().Error(s.registry.Register("a", nil))
+}
+
+func (s *AdapterRegistryTestSuite) TestGetAdapter_NotFound() {
+	_, err := s.registry.GetAdapter("a")
+	s.Require().Error(err)
+}
+
+func (s *AdapterRegistryTestSuite) TestGetNodeTypes() {
+	s.registry.Register("b", testAdapter{})
+	s.registry.Register("a", testAdapter{})
+
+	s.Equal([]string{"a", "b"}, s.registry.GetNodeTypes())
+}
+
+func (s *AdapterRegistryTestSuite) TestDefaultRegistry() {
+	registry := NewDefaultNodeAdapterRegistry()
+	builtinAdapters := getBuiltinAdapters(RegistryConfig{})
+	s.Equal(len(builtinAdapters), len(registry.GetNodeTypes()))
+
+	for nodeType := range builtinAdapters {
+		_, err := registry.GetAdapter(nodeType)
+		s.Nil(err)
+	}
+}
+
+func (s *AdapterRegistryTestSuite) TestConfiguredRegistry_CompressorMaps() {
+	m, _ := NewTableCompressorMap([]float64{2, 10}, []float64{0.9, 0.8})
+	registry := NewConfiguredNodeAdapterRegistry(RegistryConfig{
+		CompressorMaps: map[string]CompressorMap{"map": m},
+	})
+
+	adapter, err := registry.GetAdapter(CompressorNodeType)
+	s.Require().Nil(err)
+	_, err = adapter.Create(&pb.RequestData{
+		DKwargs: map[string]float64{piParam: 6},
+		SKwargs: map[string]string{mapParam: "map"},
+	}, nil)
+	s.Nil(err)
+}
+
+func TestAdapterRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(AdapterRegistryTestSuite))
+}
@@ -0,0 +1,191 @@
+package adapters
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/sink"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/pb"
+)
+
+// names of inlet node parameters
+const (
+	tStagParam    = "tStag"
+	pStagParam    = "pStag"
+	massRateParam = "massRate"
+	gasParam      = "gas"
+)
+
+const defaultMassRate = 1
+
+// NewInletAdapter constructs NodeAdapter which handles InletNodeType nodes.
+// Inlet is a source of the gas path: all its ports are outputs.
+// Creation data must contain tStag and pStag in dKwargs, massRate defaults to 1
+// and gas name in sKwargs defaults to air
+func NewInletAdapter() NodeAdapter {
+	return inletAdapter{}
+}
+
+type inletAdapter struct{}
+
+func (a inletAdapter) Create(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+	tStag, err := getDKwarg(data, tStagParam)
+	if err != nil {
+		return nil, err
+	}
+	pStag, err := getDKwarg(data, pStagParam)
+	if err != nil {
+		return nil, err
+	}
+	massRate := getOptionalDKwarg(data, massRateParam, defaultMassRate)
+	if err := a.checkParams(tStag, pStag, massRate); err != nil {
+		return nil, err
+	}
+
+	gasName := airGas
+	if name, ok := data.SKwargs[gasParam]; ok {
+		gasName = name
+	}
+	gas, ok := gasIndex[gasName]
+	if !ok {
+		return nil, common.NewError(badRequest, "unknown gas %s", gasName)
+	}
+
+	return source.NewComplexGasSourceNode(gas, tStag, pStag, massRate), nil
+}
+
+func (a inletAdapter) Update(node graph.Node, data *pb.RequestData) error {
+	iNode, err := a.cast(node)
+	if err != nil {
+		return err
+	}
+
+	tStag := getOptionalDKwarg(data, tStagParam, iNode.TStag())
+	pStag := getOptionalDKwarg(data, pStagParam, iNode.PStag())
+	massRate := getOptionalDKwarg(data, massRateParam, iNode.MassRate())
+	if err := a.checkParams(tStag, pStag, massRate); err != nil {
+		return err
+	}
+
+	if gasName, ok := data.GetSKwargs()[gasParam]; ok {
+		gas, ok := gasIndex[gasName]
+		if !ok {
+			return common.NewError(badRequest, "unknown gas %s", gasName)
+		}
+		iNode.SetGas(gas)
+	}
+
+	iNode.SetTStag(tStag)
+	iNode.SetPStag(pStag)
+	iNode.SetMassRate(massRate)
+	return nil
+}
+
+func (a inletAdapter) GetState(node graph.Node, requiredFields []string) (*pb.NodeState, error) {
+	iNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+
+	gasName, err := getGasName(iNode.Gas())
+	if err != nil {
+		return nil, err
+	}
+
+	return getNodeStringState(
+		iNode,
+		map[string]float64{
+			tStagParam:    iNode.TStag(),
+			pStagParam:    iNode.PStag(),
+			massRateParam: iNode.MassRate(),
+		},
+		map[string]string{gasParam: gasName},
+		getGasSourcePorts(iNode),
+		requiredFields,
+	)
+}
+
+func (a inletAdapter) GetPort(tag string, node graph.Node) (graph.Port, error) {
+	iNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+	return getPort(tag, getGasSourcePorts(iNode))
+}
+
+func (a inletAdapter) GetDescription() *pb.NodeDescription {
+	return &pb.NodeDescription{
+		NodeType:  InletNodeType,
+		BasePorts: getGasSourcePortDescriptions(),
+	}
+}
+
+func (a inletAdapter) checkParams(tStag, pStag, massRate float64) error {
+	if tStag <= 0 {
+		return common.NewError(badRequest, "%s must be positive (got %f)", tStagParam, tStag)
+	}
+	if pStag <= 0 {
+		return common.NewError(badRequest, "%s must be positive (got %f)", pStagParam, pStag)
+	}
+	if massRate <= 0 {
+		return common.NewError(badRequest, "%s must be positive (got %f)", massRateParam, massRate)
+	}
+	return nil
+}
+
+func (a inletAdapter) cast(node graph.Node) (source.ComplexGasSourceNode, error) {
+	iNode, ok := node.(source.ComplexGasSourceNode)
+	if !ok {
+		return nil, fmt.Errorf("node %s is not an inlet node", node.GetName())
+	}
+	return iNode, nil
+}
+
+// NewOutletAdapter constructs NodeAdapter which handles OutletNodeType nodes.
+// Outlet is a sink of the gas path: all its ports are inputs. It has no parameters
+func NewOutletAdapter() NodeAdapter {
+	return outletAdapter{}
+}
+
+type outletAdapter struct{}
+
+func (a outletAdapter) Create(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+	return sink.NewComplexGasSinkNode(), nil
+}
+
+func (a outletAdapter) Update(node graph.Node, data *pb.RequestData) error {
+	_, err := a.cast(node)
+	return err
+}
+
+func (a outletAdapter) GetState(node graph.Node, requiredFields []string) (*pb.NodeState, error) {
+	oNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+	return getNodeState(oNode, make(map[string]float64), getGasSinkPorts(oNode), requiredFields)
+}
+
+func (a outletAdapter) GetPort(tag string, node graph.Node) (graph.Port, error) {
+	oNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+	return getPort(tag, getGasSinkPorts(oNode))
+}
+
+func (a outletAdapter) GetDescription() *pb.NodeDescription {
+	return &pb.NodeDescription{
+		NodeType:  OutletNodeType,
+		BasePorts: getGasSinkPortDescriptions(),
+	}
+}
+
+func (a outletAdapter) cast(node graph.Node) (sink.ComplexGasSinkNode, error) {
+	oNode, ok := node.(sink.ComplexGasSinkNode)
+	if !ok {
+		return nil, fmt.Errorf("node %s is not an outlet node", node.GetName())
+	}
+	return oNode, nil
+}
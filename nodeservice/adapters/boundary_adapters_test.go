@@ -0,0 +1,79 @@
+package adapters
+
+import (
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/source"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type InletAdapterTestSuite struct {
+	adapterTestSuite
+}
+
+func (s *InletAdapterTestSuite) SetupTest() {
+	s.adapter = NewInletAdapter()
+	s.data = getDKwargs(map[string]float64{tStagParam: 288, pStagParam: 1e5})
+}
+
+func (s *InletAdapterTestSuite) TestCreate_OK() {
+	iNode := s.createNode().(source.ComplexGasSourceNode)
+	s.InDelta(288, iNode.TStag(), 1e-9)
+	s.InDelta(1e5, iNode.PStag(), 1e-9)
+	s.InDelta(defaultMassRate, iNode.MassRate(), 1e-9)
+	s.Equal(gases.GetAir(), iNode.Gas())
+}
+
+func (s *InletAdapterTestSuite) TestCreate_InvalidArgs() {
+	s.checkCreateErrors(
+		getDKwargs(map[string]float64{tStagParam: 288}),
+		getDKwargs(map[string]float64{tStagParam: -1, pStagParam: 1e5}),
+		&pb.RequestData{
+			DKwargs: map[string]float64{tStagParam: 288, pStagParam: 1e5},
+			SKwargs: map[string]string{gasParam: "unknown"},
+		},
+	)
+}
+
+func (s *InletAdapterTestSuite) TestUpdate() {
+	node := s.createNode()
+
+	s.Require().Nil(s.adapter.Update(node, getDKwargs(map[string]float64{tStagParam: 300})))
+	s.InDelta(300, node.(source.ComplexGasSourceNode).TStag(), 1e-9)
+	s.checkUpdateError(node, &pb.RequestData{SKwargs: map[string]string{gasParam: "unknown"}})
+
+	state, err := s.adapter.GetState(node, []string{tStagParam})
+	s.Require().Nil(err)
+	s.InDelta(300, state.State.NumValues[tStagParam], 1e-9)
+	s.Equal(0, len(state.State.StringValues))
+
+	state, err = s.adapter.GetState(node, nil)
+	s.Require().Nil(err)
+	s.Equal(airGas, state.State.StringValues[gasParam])
+}
+
+type OutletAdapterTestSuite struct {
+	adapterTestSuite
+}
+
+func (s *OutletAdapterTestSuite) SetupTest() {
+	s.adapter = NewOutletAdapter()
+}
+
+func (s *OutletAdapterTestSuite) TestUpdate() {
+	s.Nil(s.adapter.Update(s.createNode(), nil))
+}
+
+func (s *OutletAdapterTestSuite) TestGetPort_NoOutput() {
+	_, err := s.adapter.GetPort(gasOutput, s.createNode())
+	s.Error(err)
+}
+
+func TestInletAdapterTestSuite(t *testing.T) {
+	suite.Run(t, new(InletAdapterTestSuite))
+}
+
+func TestOutletAdapterTestSuite(t *testing.T) {
+	suite.Run(t, new(OutletAdapterTestSuite))
+}
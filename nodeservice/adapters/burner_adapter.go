@@ -0,0 +1,170 @@
+package adapters
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/material/fuel"
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/pb"
+)
+
+// names of burner node parameters
+const (
+	fuelParam      = "fuel"
+	tGasParam      = "tGas"
+	fuelRateParam  = "fuelRate"
+	alphaParam     = "alpha"
+	initAlphaParam = "initAlpha"
+	t0Param        = "t0"
+)
+
+const (
+	defaultInitAlpha = 3
+	defaultT0        = 288
+)
+
+// fuel names which can be used in burner
+const (
+	ch4Fuel = "ch4"
+)
+
+var fuelIndex = map[string]fuel.GasFuel{
+	ch4Fuel: fuel.GetCH4(),
+}
+
+// NewBurnerAdapter constructs NodeAdapter which handles BurnerNodeType nodes
+// creation data must contain fuel name in sKwargs and eta, sigma and exactly one
+// of tGas (target gas temperature) and fuelRate (relative fuel mass rate) in dKwargs
+func NewBurnerAdapter() NodeAdapter {
+	return burnerAdapter{}
+}
+
+type burnerAdapter struct{}
+
+func (a burnerAdapter) Create(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+	eta, err := getDKwarg(data, etaParam)
+	if err != nil {
+		return nil, err
+	}
+	sigma, err := getDKwarg(data, sigmaParam)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.checkParams(eta, sigma); err != nil {
+		return nil, err
+	}
+
+	fuelName := data.SKwargs[fuelParam]
+	gasFuel, ok := fuelIndex[fuelName]
+	if !ok {
+		return nil, common.NewError(badRequest, "unknown fuel \"%s\"", fuelName)
+	}
+
+	t0 := getOptionalDKwarg(data, t0Param, defaultT0)
+	precision := getOptionalDKwarg(data, precisionParam, defaultPrecision)
+
+	tGas, tGasOk := data.DKwargs[tGasParam]
+	fuelRate, fuelRateOk := data.DKwargs[fuelRateParam]
+	switch {
+	case tGasOk && fuelRateOk:
+		return nil, common.NewError(badRequest, "%s and %s can not be specified simultaneously", tGasParam, fuelRateParam)
+	case tGasOk:
+		initAlpha := getOptionalDKwarg(data, initAlphaParam, defaultInitAlpha)
+		return constructive.NewBurnerNode(gasFuel, tGas, eta, sigma, initAlpha, t0, precision), nil
+	case fuelRateOk:
+		return constructive.NewParametricBurnerNode(gasFuel, fuelRate, eta, sigma, t0, precision), nil
+	default:
+		return nil, common.NewError(badRequest, "either %s or %s must be specified", tGasParam, fuelRateParam)
+	}
+}
+
+func (a burnerAdapter) Update(node graph.Node, data *pb.RequestData) error {
+	bNode, err := a.cast(node)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := data.GetSKwargs()[fuelParam]; ok {
+		return common.NewError(badRequest, "fuel can not be changed after creation")
+	}
+
+	eta := getOptionalDKwarg(data, etaParam, bNode.Eta())
+	sigma := getOptionalDKwarg(data, sigmaParam, bNode.Sigma())
+	if err := a.checkParams(eta, sigma); err != nil {
+		return err
+	}
+
+	tGas, tGasOk := data.GetDKwargs()[tGasParam]
+	fuelRate, fuelRateOk := data.GetDKwargs()[fuelRateParam]
+	switch n := bNode.(type) {
+	case constructive.ParametricBurnerNode:
+		if tGasOk {
+			return common.NewError(badRequest, "%s can not be set on burner with fixed fuel rate", tGasParam)
+		}
+		if fuelRateOk {
+			n.SetFuelRateRel(fuelRate)
+		}
+	case constructive.FixedTGasBurnerNode:
+		if fuelRateOk {
+			return common.NewError(badRequest, "%s can not be set on burner with fixed gas temperature", fuelRateParam)
+		}
+		if tGasOk {
+			n.SetTGas(tGas)
+		}
+	}
+
+	bNode.SetEta(eta)
+	bNode.SetSigma(sigma)
+	return nil
+}
+
+func (a burnerAdapter) GetState(node graph.Node, requiredFields []string) (*pb.NodeState, error) {
+	bNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return getNodeState(
+		bNode,
+		map[string]float64{
+			tGasParam:     bNode.TGas(),
+			fuelRateParam: bNode.FuelRateRel(),
+			alphaParam:    bNode.Alpha(),
+			etaParam:      bNode.Eta(),
+			sigmaParam:    bNode.Sigma(),
+		},
+		getGasChannelPorts(bNode),
+		requiredFields,
+	)
+}
+
+func (a burnerAdapter) GetPort(tag string, node graph.Node) (graph.Port, error) {
+	bNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+	return getPort(tag, getGasChannelPorts(bNode))
+}
+
+func (a burnerAdapter) GetDescription() *pb.NodeDescription {
+	return &pb.NodeDescription{
+		NodeType:  BurnerNodeType,
+		BasePorts: getGasChannelPortDescriptions(),
+	}
+}
+
+func (a burnerAdapter) checkParams(eta, sigma float64) error {
+	if err := checkFraction(etaParam, eta); err != nil {
+		return err
+	}
+	return checkFraction(sigmaParam, sigma)
+}
+
+func (a burnerAdapter) cast(node graph.Node) (constructive.BurnerNode, error) {
+	bNode, ok := node.(constructive.BurnerNode)
+	if !ok {
+		return nil, fmt.Errorf("node %s is not a burner node", node.GetName())
+	}
+	return bNode, nil
+}
@@ -0,0 +1,77 @@
+package adapters
+
+import (
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type BurnerAdapterTestSuite struct {
+	adapterTestSuite
+}
+
+func (s *BurnerAdapterTestSuite) SetupTest() {
+	s.adapter = NewBurnerAdapter()
+	s.data = s.getData(tGasParam, 1500)
+}
+
+func (s *BurnerAdapterTestSuite) TestCreate_TGas() {
+	bNode := s.createNode().(constructive.BurnerNode)
+	s.InDelta(1500, bNode.TGas(), 1e-9)
+	s.InDelta(0.99, bNode.Eta(), 1e-9)
+	s.InDelta(0.96, bNode.Sigma(), 1e-9)
+}
+
+func (s *BurnerAdapterTestSuite) TestCreate_FuelRate() {
+	node, err := s.adapter.Create(s.getData(fuelRateParam, 0.02), nil)
+	s.Require().Nil(err)
+
+	_, ok := node.(constructive.ParametricBurnerNode)
+	s.Require().True(ok)
+	s.InDelta(0.02, node.(constructive.BurnerNode).FuelRateRel(), 1e-9)
+}
+
+func (s *BurnerAdapterTestSuite) TestCreate_InvalidArgs() {
+	both := s.getData(tGasParam, 1500)
+	both.DKwargs[fuelRateParam] = 0.02
+
+	noFuel := s.getData(tGasParam, 1500)
+	noFuel.SKwargs = nil
+
+	badEta := s.getData(tGasParam, 1500)
+	badEta.DKwargs[etaParam] = 2
+
+	s.checkCreateErrors(both, noFuel, badEta, s.getData("unknown", 1))
+}
+
+func (s *BurnerAdapterTestSuite) TestUpdate() {
+	node := s.createNode()
+
+	s.Require().Nil(s.adapter.Update(node, getDKwargs(map[string]float64{tGasParam: 1600})))
+	s.InDelta(1600, node.(constructive.BurnerNode).TGas(), 1e-9)
+
+	s.checkUpdateError(node, getDKwargs(map[string]float64{fuelRateParam: 0.02}))
+	s.checkUpdateError(node, &pb.RequestData{SKwargs: map[string]string{fuelParam: ch4Fuel}})
+}
+
+func (s *BurnerAdapterTestSuite) TestGetState() {
+	state, err := s.adapter.GetState(s.createNode(), []string{fuelRateParam})
+	s.Require().Nil(err)
+	s.Equal(1, len(state.State.NumValues))
+}
+
+func (s *BurnerAdapterTestSuite) getData(key string, val float64) *pb.RequestData {
+	return &pb.RequestData{
+		DKwargs: map[string]float64{
+			etaParam:   0.99,
+			sigmaParam: 0.96,
+			key:        val,
+		},
+		SKwargs: map[string]string{fuelParam: ch4Fuel},
+	}
+}
+
+func TestBurnerAdapterTestSuite(t *testing.T) {
+	suite.Run(t, new(BurnerAdapterTestSuite))
+}
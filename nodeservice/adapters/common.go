@@ -1,5 +1,14 @@
 package adapters
 
+import (
+	"fmt"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes"
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/pb"
+	"sort"
+)
+
 const (
 	temperatureInput = "temperature_input"
 	pressureInput    = "pressure_input"
@@ -10,4 +19,182 @@ const (
 	pressureOutput    = "pressure_output"
 	massRateOutput    = "mass_rate_output"
 	gasOutput         = "gas_output"
+
+	powerInput  = "power_input"
+	powerOutput = "power_output"
+	// power is a tag of context dependent power port
+	power = "power"
 )
+
+// badRequest is a code of errors caused by invalid request data
+const badRequest = pb.StatusCode_BAD_REQUEST
+
+type portType = pb.NodeDescription_AttachedPortDescription_PortType
+
+func getGasChannelPorts(channel nodes.ComplexGasChannel) map[string]graph.Port {
+	result := getGasSinkPorts(channel)
+	for tag, port := range getGasSourcePorts(channel) {
+		result[tag] = port
+	}
+	return result
+}
+
+func getGasSinkPorts(sink nodes.ComplexGasSink) map[string]graph.Port {
+	return map[string]graph.Port{
+		temperatureInput: sink.TemperatureInput(),
+		pressureInput:    sink.PressureInput(),
+		massRateInput:    sink.MassRateInput(),
+		gasInput:         sink.GasInput(),
+	}
+}
+
+func getGasSourcePorts(source nodes.ComplexGasSource) map[string]graph.Port {
+	return map[string]graph.Port{
+		temperatureOutput: source.TemperatureOutput(),
+		pressureOutput:    source.PressureOutput(),
+		massRateOutput:    source.MassRateOutput(),
+		gasOutput:         source.GasOutput(),
+	}
+}
+
+func getGasChannelPortDescriptions() []*pb.NodeDescription_AttachedPortDescription {
+	return append(getGasSinkPortDescriptions(), getGasSourcePortDescriptions()...)
+}
+
+func getGasSinkPortDescriptions() []*pb.NodeDescription_AttachedPortDescription {
+	return []*pb.NodeDescription_AttachedPortDescription{
+		getPortDescription(temperatureInput, pb.NodeDescription_AttachedPortDescription_INPUT),
+		getPortDescription(pressureInput, pb.NodeDescription_AttachedPortDescription_INPUT),
+		getPortDescription(massRateInput, pb.NodeDescription_AttachedPortDescription_INPUT),
+		getPortDescription(gasInput, pb.NodeDescription_AttachedPortDescription_INPUT),
+	}
+}
+
+func getGasSourcePortDescriptions() []*pb.NodeDescription_AttachedPortDescription {
+	return []*pb.NodeDescription_AttachedPortDescription{
+		getPortDescription(temperatureOutput, pb.NodeDescription_AttachedPortDescription_OUTPUT),
+		getPortDescription(pressureOutput, pb.NodeDescription_AttachedPortDescription_OUTPUT),
+		getPortDescription(massRateOutput, pb.NodeDescription_AttachedPortDescription_OUTPUT),
+		getPortDescription(gasOutput, pb.NodeDescription_AttachedPortDescription_OUTPUT),
+	}
+}
+
+// getPrefixedPorts returns copy of ports with tags prefixed with prefix.
+// It is used to distinguish ports of the same kind which belong to different paths of a node
+func getPrefixedPorts(prefix string, ports map[string]graph.Port) map[string]graph.Port {
+	result := make(map[string]graph.Port, len(ports))
+	for tag, port := range ports {
+		result[getPrefixedTag(prefix, tag)] = port
+	}
+	return result
+}
+
+func getPrefixedPortDescriptions(
+	prefix string, descriptions []*pb.NodeDescription_AttachedPortDescription,
+) []*pb.NodeDescription_AttachedPortDescription {
+	result := make([]*pb.NodeDescription_AttachedPortDescription, len(descriptions))
+	for i, d := range descriptions {
+		result[i] = getPortDescription(getPrefixedTag(prefix, d.Description.Prefix), d.Type)
+	}
+	return result
+}
+
+func getPrefixedTag(prefix, tag string) string {
+	return prefix + "_" + tag
+}
+
+func getPortDescription(prefix string, t portType) *pb.NodeDescription_AttachedPortDescription {
+	return &pb.NodeDescription_AttachedPortDescription{
+		Description: &pb.PortDescription{Prefix: prefix},
+		Type:        t,
+	}
+}
+
+func getPort(tag string, ports map[string]graph.Port) (graph.Port, error) {
+	port, ok := ports[tag]
+	if !ok {
+		return nil, fmt.Errorf("port %s not found", tag)
+	}
+	return port, nil
+}
+
+// getNodeState collects node values and states of all the ports which have state set
+// requiredFields are applied to node values only
+func getNodeState(
+	node graph.Node, values map[string]float64, ports map[string]graph.Port, requiredFields []string,
+) (*pb.NodeState, error) {
+	return getNodeStringState(node, values, nil, ports, requiredFields)
+}
+
+// getNodeStringState works as getNodeState but also puts string values of the node to its state
+func getNodeStringState(
+	node graph.Node, values map[string]float64, stringValues map[string]string,
+	ports map[string]graph.Port, requiredFields []string,
+) (*pb.NodeState, error) {
+	if stringValues == nil {
+		stringValues = make(map[string]string)
+	}
+	state := &pb.State{
+		NumValues:    values,
+		StringValues: stringValues,
+	}
+	if err := filterState(state, requiredFields); err != nil {
+		return nil, err
+	}
+
+	tags := make([]string, 0, len(ports))
+	for tag := range ports {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	portStates := make([]*pb.PortState, 0, len(tags))
+	for _, tag := range tags {
+		portState := ports[tag].GetState()
+		if portState == nil {
+			continue
+		}
+
+		pbState, err := PortStateToPB(tag, portState, nil)
+		if err != nil {
+			return nil, err
+		}
+		portStates = append(portStates, pbState)
+	}
+
+	return &pb.NodeState{
+		Name:       node.GetName(),
+		State:      state,
+		PortStates: portStates,
+	}, nil
+}
+
+// getDKwarg extracts required float argument from data
+func getDKwarg(data *pb.RequestData, name string) (float64, error) {
+	if data == nil {
+		return 0, common.NewError(badRequest, "argument %s not found: empty request data", name)
+	}
+	val, ok := data.DKwargs[name]
+	if !ok {
+		return 0, common.NewError(badRequest, "argument %s not found", name)
+	}
+	return val, nil
+}
+
+// getOptionalDKwarg extracts float argument from data and returns defaultVal if it is not found
+func getOptionalDKwarg(data *pb.RequestData, name string, defaultVal float64) float64 {
+	if data == nil {
+		return defaultVal
+	}
+	if val, ok := data.DKwargs[name]; ok {
+		return val
+	}
+	return defaultVal
+}
+
+func checkFraction(name string, val float64) error {
+	if val <= 0 || val > 1 {
+		return common.NewError(badRequest, "%s must be in range (0, 1] (got %f)", name, val)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package adapters
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/networkservice/repr"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+// adapterTestSuite checks behaviour common to all the adapters. Adapter suites embed it
+// and set adapter with valid creation arguments in their SetupTest
+type adapterTestSuite struct {
+	suite.Suite
+	adapter    NodeAdapter
+	data       *pb.RequestData
+	multiPorts map[string]int32
+}
+
+func (s *adapterTestSuite) TestDescription() {
+	checkDescription(s.T(), s.adapter, s.createNode(), s.multiPorts)
+}
+
+func (s *adapterTestSuite) TestGetPort_NotFound() {
+	_, err := s.adapter.GetPort("unknown", s.createNode())
+	s.Error(err)
+}
+
+func (s *adapterTestSuite) TestGetState_UnknownField() {
+	_, err := s.adapter.GetState(s.createNode(), []string{"unknown"})
+	s.Error(err)
+}
+
+func (s *adapterTestSuite) TestForeignNode() {
+	node := graph.NewTestNode(0, 0, true, nil)
+
+	s.Error(s.adapter.Update(node, s.data))
+	_, err := s.adapter.GetState(node, nil)
+	s.Error(err)
+	_, err = s.adapter.GetPort(temperatureInput, node)
+	s.Error(err)
+}
+
+// createNode creates node out of valid arguments of the suite
+func (s *adapterTestSuite) createNode() graph.Node {
+	node, err := s.adapter.Create(s.data, s.multiPorts)
+	s.Require().Nil(err)
+	return node
+}
+
+// checkCreateErrors checks that node can not be created out of any of data and data is reported as bad request
+func (s *adapterTestSuite) checkCreateErrors(data ...*pb.RequestData) {
+	for i, d := range data {
+		_, err := s.adapter.Create(d, s.multiPorts)
+		s.Require().Error(err, "case %d", i)
+		s.EqualValues(pb.StatusCode_BAD_REQUEST, common.GetCode(err, pb.StatusCode_INTERNAL_ERROR), "case %d", i)
+	}
+}
+
+// checkUpdateError checks that node can not be updated with data and data is reported as bad request
+func (s *adapterTestSuite) checkUpdateError(node graph.Node, data *pb.RequestData) {
+	err := s.adapter.Update(node, data)
+	s.Require().Error(err)
+	s.EqualValues(pb.StatusCode_BAD_REQUEST, common.GetCode(err, pb.StatusCode_INTERNAL_ERROR))
+}
+
+// getDKwargs wraps numeric arguments into request data
+func getDKwargs(dKwargs map[string]float64) *pb.RequestData {
+	return &pb.RequestData{DKwargs: dKwargs}
+}
+
+// checkDescription checks that description of adapter is accepted by representation nodes
+// and that all the ports of description can be extracted from node
+func checkDescription(t *testing.T, adapter NodeAdapter, node graph.Node, multiPorts map[string]int32) {
+	description := adapter.GetDescription()
+
+	multiPortMap := make(map[string]int, len(multiPorts))
+	for prefix, cnt := range multiPorts {
+		multiPortMap[prefix] = int(cnt)
+	}
+	_, err := repr.NewRepresentationNode(description, multiPortMap)
+	assert.Nil(t, err)
+
+	for _, pd := range description.BasePorts {
+		prefix := pd.Description.Prefix
+		tags := []string{prefix}
+		if pd.Description.IsMulti {
+			tags = common.GetMultiPortTags(prefix, multiPortMap[prefix])
+		}
+
+		for _, tag := range tags {
+			port, err := adapter.GetPort(tag, node)
+			assert.Nil(t, err, tag)
+			assert.NotNil(t, port, tag)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package adapters
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/pb"
+)
+
+// names of compressor node parameters
+const (
+	piParam        = "pi"
+	etaParam       = "eta"
+	lSpecificParam = "lSpecific"
+	mapParam       = "map"
+	precisionParam = "precision"
+)
+
+const defaultPrecision = 1e-5
+
+// NewCompressorAdapter constructs NodeAdapter which handles CompressorNodeType nodes
+// creation data must contain pi and either eta in dKwargs or name of the map from maps in sKwargs.
+// Efficiency is taken from the map only when map is specified in creation or update data.
+// Adapter does not remember the map of the node, so update of pi alone keeps the current eta:
+// map must be specified in the same update to recalculate eta for the new pi
+func NewCompressorAdapter(maps map[string]CompressorMap) NodeAdapter {
+	mapsCopy := make(map[string]CompressorMap)
+	for name, m := range maps {
+		mapsCopy[name] = m
+	}
+	return compressorAdapter{maps: mapsCopy}
+}
+
+type compressorAdapter struct {
+	maps map[string]CompressorMap
+}
+
+func (a compressorAdapter) Create(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+	pi, err := getDKwarg(data, piParam)
+	if err != nil {
+		return nil, err
+	}
+
+	var eta float64
+	if _, ok := data.SKwargs[mapParam]; ok {
+		eta, err = a.getMapEta(data, pi)
+	} else {
+		eta, err = getDKwarg(data, etaParam)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.checkParams(pi, eta); err != nil {
+		return nil, err
+	}
+	return constructive.NewCompressorNode(eta, pi, getOptionalDKwarg(data, precisionParam, defaultPrecision)), nil
+}
+
+func (a compressorAdapter) Update(node graph.Node, data *pb.RequestData) error {
+	cNode, err := a.cast(node)
+	if err != nil {
+		return err
+	}
+
+	pi := getOptionalDKwarg(data, piParam, cNode.PiStag())
+	eta := getOptionalDKwarg(data, etaParam, cNode.Eta())
+	if data != nil {
+		if _, ok := data.SKwargs[mapParam]; ok {
+			if eta, err = a.getMapEta(data, pi); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := a.checkParams(pi, eta); err != nil {
+		return err
+	}
+	cNode.SetPiStag(pi)
+	cNode.SetEtaAd(eta)
+	return nil
+}
+
+func (a compressorAdapter) GetState(node graph.Node, requiredFields []string) (*pb.NodeState, error) {
+	cNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return getNodeState(
+		cNode,
+		map[string]float64{
+			piParam:        cNode.PiStag(),
+			etaParam:       cNode.Eta(),
+			lSpecificParam: cNode.LSpecific(),
+		},
+		a.getPorts(cNode),
+		requiredFields,
+	)
+}
+
+func (a compressorAdapter) GetPort(tag string, node graph.Node) (graph.Port, error) {
+	cNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+	return getPort(tag, a.getPorts(cNode))
+}
+
+func (a compressorAdapter) GetDescription() *pb.NodeDescription {
+	return &pb.NodeDescription{
+		NodeType: CompressorNodeType,
+		BasePorts: append(
+			getGasChannelPortDescriptions(),
+			getPortDescription(powerOutput, pb.NodeDescription_AttachedPortDescription_OUTPUT),
+		),
+	}
+}
+
+func (a compressorAdapter) getPorts(node constructive.CompressorNode) map[string]graph.Port {
+	result := getGasChannelPorts(node)
+	result[powerOutput] = node.PowerOutput()
+	return result
+}
+
+func (a compressorAdapter) getMapEta(data *pb.RequestData, pi float64) (float64, error) {
+	if _, ok := data.DKwargs[etaParam]; ok {
+		return 0, common.NewError(badRequest, "%s and %s can not be specified simultaneously", etaParam, mapParam)
+	}
+
+	name := data.SKwargs[mapParam]
+	m, ok := a.maps[name]
+	if !ok {
+		return 0, common.NewError(badRequest, "compressor map %s not found", name)
+	}
+
+	eta, err := m.GetEta(pi)
+	if err != nil {
+		// pressure ratio is out of map range
+		return 0, common.NewError(badRequest, "%v", err)
+	}
+	return eta, nil
+}
+
+func (a compressorAdapter) checkParams(pi, eta float64) error {
+	if pi < 1 {
+		return common.NewError(badRequest, "%s must not be less than 1 (got %f)", piParam, pi)
+	}
+	return checkFraction(etaParam, eta)
+}
+
+func (a compressorAdapter) cast(node graph.Node) (constructive.CompressorNode, error) {
+	cNode, ok := node.(constructive.CompressorNode)
+	if !ok {
+		return nil, fmt.Errorf("node %s is not a compressor node", node.GetName())
+	}
+	return cNode, nil
+}
@@ -0,0 +1,91 @@
+package adapters
+
+import (
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+const testMapName = "testMap"
+
+type CompressorAdapterTestSuite struct {
+	adapterTestSuite
+}
+
+func (s *CompressorAdapterTestSuite) SetupTest() {
+	m, _ := NewTableCompressorMap([]float64{2, 10}, []float64{0.9, 0.8})
+	s.adapter = NewCompressorAdapter(map[string]CompressorMap{testMapName: m})
+	s.data = getDKwargs(map[string]float64{piParam: 6, etaParam: 0.86})
+}
+
+func (s *CompressorAdapterTestSuite) TestCreate_OK() {
+	cNode := s.createNode().(constructive.CompressorNode)
+	s.InDelta(6, cNode.PiStag(), 1e-9)
+	s.InDelta(0.86, cNode.Eta(), 1e-9)
+}
+
+func (s *CompressorAdapterTestSuite) TestCreate_Map() {
+	data := &pb.RequestData{
+		DKwargs: map[string]float64{piParam: 6},
+		SKwargs: map[string]string{mapParam: testMapName},
+	}
+	node, err := s.adapter.Create(data, nil)
+	s.Require().Nil(err)
+	s.InDelta(0.85, node.(constructive.CompressorNode).Eta(), 1e-9)
+}
+
+func (s *CompressorAdapterTestSuite) TestCreate_InvalidArgs() {
+	s.checkCreateErrors(
+		getDKwargs(map[string]float64{piParam: 6}),
+		getDKwargs(map[string]float64{etaParam: 0.86}),
+		getDKwargs(map[string]float64{piParam: 0.5, etaParam: 0.86}),
+		getDKwargs(map[string]float64{piParam: 6, etaParam: 1.2}),
+		&pb.RequestData{
+			DKwargs: map[string]float64{piParam: 6},
+			SKwargs: map[string]string{mapParam: "unknown"},
+		},
+		&pb.RequestData{
+			DKwargs: map[string]float64{piParam: 6, etaParam: 0.86},
+			SKwargs: map[string]string{mapParam: testMapName},
+		},
+	)
+}
+
+func (s *CompressorAdapterTestSuite) TestUpdate() {
+	node := s.createNode()
+	cNode := node.(constructive.CompressorNode)
+
+	s.Require().Nil(s.adapter.Update(node, getDKwargs(map[string]float64{piParam: 8})))
+	s.InDelta(8, cNode.PiStag(), 1e-9)
+	s.InDelta(0.86, cNode.Eta(), 1e-9)
+
+	s.Require().Nil(s.adapter.Update(node, &pb.RequestData{SKwargs: map[string]string{mapParam: testMapName}}))
+	s.InDelta(0.825, cNode.Eta(), 1e-9)
+
+	// map is not remembered, it must be specified to recalculate eta
+	s.Require().Nil(s.adapter.Update(node, getDKwargs(map[string]float64{piParam: 4})))
+	s.InDelta(0.825, cNode.Eta(), 1e-9)
+	s.Require().Nil(s.adapter.Update(node, &pb.RequestData{
+		DKwargs: map[string]float64{piParam: 4},
+		SKwargs: map[string]string{mapParam: testMapName},
+	}))
+	s.InDelta(0.875, cNode.Eta(), 1e-9)
+
+	s.checkUpdateError(node, &pb.RequestData{
+		DKwargs: map[string]float64{piParam: 100},
+		SKwargs: map[string]string{mapParam: testMapName},
+	})
+}
+
+func (s *CompressorAdapterTestSuite) TestGetState() {
+	state, err := s.adapter.GetState(s.createNode(), []string{piParam, etaParam})
+	s.Require().Nil(err)
+	s.Equal(2, len(state.State.NumValues))
+	s.InDelta(6, state.State.NumValues[piParam], 1e-9)
+	s.InDelta(0.86, state.State.NumValues[etaParam], 1e-9)
+}
+
+func TestCompressorAdapterTestSuite(t *testing.T) {
+	suite.Run(t, new(CompressorAdapterTestSuite))
+}
@@ -0,0 +1,64 @@
+package adapters
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CompressorMap is a compressor characteristic which determines
+// compressor efficiency by its pressure ratio
+type CompressorMap interface {
+	GetEta(piStag float64) (float64, error)
+}
+
+// NewTableCompressorMap constructs CompressorMap which linearly interpolates
+// efficiency between points of the table. Pressure ratios must be unique
+func NewTableCompressorMap(piValues, etaValues []float64) (CompressorMap, error) {
+	if len(piValues) != len(etaValues) {
+		return nil, fmt.Errorf("length of arguments are not equal")
+	}
+	if len(piValues) < 2 {
+		return nil, fmt.Errorf("at least two points are required")
+	}
+
+	points := make([]mapPoint, len(piValues))
+	for i := range piValues {
+		points[i] = mapPoint{pi: piValues[i], eta: etaValues[i]}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].pi < points[j].pi
+	})
+
+	for i := 1; i != len(points); i++ {
+		if points[i].pi == points[i-1].pi {
+			return nil, fmt.Errorf("duplicate pressure ratio %f", points[i].pi)
+		}
+	}
+	return &tableCompressorMap{points: points}, nil
+}
+
+type mapPoint struct {
+	pi  float64
+	eta float64
+}
+
+type tableCompressorMap struct {
+	points []mapPoint
+}
+
+func (m *tableCompressorMap) GetEta(piStag float64) (float64, error) {
+	first, last := m.points[0], m.points[len(m.points)-1]
+	if piStag < first.pi || piStag > last.pi {
+		return 0, fmt.Errorf("pressure ratio %f is out of map range [%f, %f]", piStag, first.pi, last.pi)
+	}
+
+	i := sort.Search(len(m.points), func(i int) bool {
+		return m.points[i].pi >= piStag
+	})
+	if i == 0 {
+		return first.eta, nil
+	}
+
+	left, right := m.points[i-1], m.points[i]
+	return left.eta + (right.eta-left.eta)*(piStag-left.pi)/(right.pi-left.pi), nil
+}
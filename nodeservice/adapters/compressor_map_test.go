@@ -0,0 +1,43 @@
+package adapters
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewTableCompressorMap_InvalidArgs(t *testing.T) {
+	_, err := NewTableCompressorMap([]float64{1, 2}, []float64{0.8})
+	assert.Error(t, err)
+
+	_, err = NewTableCompressorMap([]float64{1}, []float64{0.8})
+	assert.Error(t, err)
+
+	_, err = NewTableCompressorMap([]float64{2, 2}, []float64{0.8, 0.9})
+	assert.Error(t, err)
+}
+
+func TestTableCompressorMap_GetEta(t *testing.T) {
+	m, err := NewTableCompressorMap([]float64{10, 2, 6}, []float64{0.8, 0.9, 0.86})
+	assert.Nil(t, err)
+
+	tc := []struct {
+		pi  float64
+		eta float64
+	}{
+		{2, 0.9},
+		{4, 0.88},
+		{6, 0.86},
+		{8, 0.83},
+		{10, 0.8},
+	}
+	for _, c := range tc {
+		eta, err := m.GetEta(c.pi)
+		assert.Nil(t, err, "pi %f", c.pi)
+		assert.InDelta(t, c.eta, eta, 1e-9, "pi %f", c.pi)
+	}
+
+	_, err = m.GetEta(1)
+	assert.Error(t, err)
+	_, err = m.GetEta(11)
+	assert.Error(t, err)
+}
@@ -5,9 +5,10 @@ import (
 	"github.com/Sovianum/turbonetwork/pb"
 )
 
-// NodeAdapter implements base operations which are necessary to plug node to the total network
+// NodeAdapter implements base operations which are necessary to plug node to the total network.
+// Create and Update return common.Error with BAD_REQUEST code if request data is invalid
 type NodeAdapter interface {
-	Create(data *pb.RequestData) (graph.Node, error)
+	Create(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error)
 	Update(node graph.Node, data *pb.RequestData) error
 	GetState(node graph.Node, requiredFields []string) (*pb.NodeState, error)
 	GetPort(tag string, node graph.Node) (graph.Port, error)
@@ -3,4 +3,11 @@ package adapters
 // names of provided node types
 const (
 	PressureLossNodeType = "pressureLossNode"
+	CompressorNodeType   = "compressorNode"
+	TurbineNodeType      = "turbineNode"
+	BurnerNodeType       = "burnerNode"
+	InletNodeType        = "inletNode"
+	OutletNodeType       = "outletNode"
+	ShaftNodeType        = "shaftNode"
+	RegeneratorNodeType  = "regeneratorNode"
 )
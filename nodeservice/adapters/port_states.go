@@ -0,0 +1,148 @@
+package adapters
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbonetwork/pb"
+)
+
+// names of the fields used in serialized port states
+const (
+	temperatureField = "tStag"
+	pressureField    = "pStag"
+	massRateField    = "massRate"
+	powerField       = "lSpecific"
+	numberField      = "num"
+	gasField         = "gas"
+)
+
+// gas names which can be passed through the network
+const (
+	airGas = "air"
+)
+
+var gasIndex = map[string]gases.Gas{
+	airGas: gases.GetAir(),
+}
+
+// PortStateToPB converts state of the port to its protobuf representation
+// if requiredFields is not empty only listed fields are put to the result
+func PortStateToPB(tag string, state graph.PortState, requiredFields []string) (*pb.PortState, error) {
+	if state == nil {
+		return nil, fmt.Errorf("state of port %s is not set", tag)
+	}
+
+	result := &pb.State{
+		NumValues:    make(map[string]float64),
+		StringValues: make(map[string]string),
+	}
+
+	switch s := state.(type) {
+	case states.TemperaturePortState:
+		result.NumValues[temperatureField] = s.TStag
+	case states.PressurePortState:
+		result.NumValues[pressureField] = s.PStag
+	case states.MassRatePortState:
+		result.NumValues[massRateField] = s.MassRate
+	case states.PowerPortState:
+		result.NumValues[powerField] = s.LSpecific
+	case graph.NumberPortState:
+		result.NumValues[numberField] = s.Num
+	case states.GasPortState:
+		name, err := getGasName(s.Gas)
+		if err != nil {
+			return nil, err
+		}
+		result.StringValues[gasField] = name
+	default:
+		return nil, fmt.Errorf("unsupported state type %T of port %s", state, tag)
+	}
+
+	if err := filterState(result, requiredFields); err != nil {
+		return nil, fmt.Errorf("failed to extract state of port %s: %s", tag, err.Error())
+	}
+
+	return &pb.PortState{
+		Tag:   tag,
+		State: result,
+	}, nil
+}
+
+// PortStateFromPB converts protobuf representation of the port state to the port state
+// type of the state is determined by the only field set in the state
+func PortStateFromPB(portState *pb.PortState) (graph.PortState, error) {
+	if portState == nil || portState.State == nil {
+		return nil, fmt.Errorf("empty port state")
+	}
+
+	numValues := portState.State.NumValues
+	stringValues := portState.State.StringValues
+	if l := len(numValues) + len(stringValues); l != 1 {
+		return nil, fmt.Errorf("port state must contain exactly one value (got %d)", l)
+	}
+
+	for key, val := range numValues {
+		switch key {
+		case temperatureField:
+			return states.NewTemperaturePortState(val), nil
+		case pressureField:
+			return states.NewPressurePortState(val), nil
+		case massRateField:
+			return states.NewMassRatePortState(val), nil
+		case powerField:
+			return states.NewPowerPortState(val), nil
+		case numberField:
+			return graph.NewNumberPortState(val), nil
+		default:
+			return nil, fmt.Errorf("unknown numeric field %s", key)
+		}
+	}
+
+	for key, val := range stringValues {
+		if key != gasField {
+			return nil, fmt.Errorf("unknown string field %s", key)
+		}
+		gas, ok := gasIndex[val]
+		if !ok {
+			return nil, fmt.Errorf("unknown gas %s", val)
+		}
+		return states.NewGasPortState(gas), nil
+	}
+
+	return nil, fmt.Errorf("empty port state")
+}
+
+func getGasName(gas gases.Gas) (string, error) {
+	for name, g := range gasIndex {
+		if g == gas {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("gas %v can not be serialized", gas)
+}
+
+func filterState(state *pb.State, requiredFields []string) error {
+	if len(requiredFields) == 0 {
+		return nil
+	}
+
+	numValues := make(map[string]float64)
+	stringValues := make(map[string]string)
+	for _, field := range requiredFields {
+		if val, ok := state.NumValues[field]; ok {
+			numValues[field] = val
+			continue
+		}
+		if val, ok := state.StringValues[field]; ok {
+			stringValues[field] = val
+			continue
+		}
+		return fmt.Errorf("field %s not found", field)
+	}
+
+	state.NumValues = numValues
+	state.StringValues = stringValues
+	return nil
+}
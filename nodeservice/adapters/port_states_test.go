@@ -0,0 +1,61 @@
+package adapters
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPortStateConversion(t *testing.T) {
+	tc := []graph.PortState{
+		states.NewTemperaturePortState(300),
+		states.NewPressurePortState(1e5),
+		states.NewMassRatePortState(10),
+		states.NewPowerPortState(2e5),
+		graph.NewNumberPortState(1),
+		states.NewGasPortState(gases.GetAir()),
+	}
+
+	for i, state := range tc {
+		pbState, err := PortStateToPB("tag", state, nil)
+		assert.Nil(t, err, "case %d", i)
+		assert.Equal(t, "tag", pbState.Tag, "case %d", i)
+
+		restored, err := PortStateFromPB(pbState)
+		assert.Nil(t, err, "case %d", i)
+		assert.Equal(t, state, restored, "case %d", i)
+	}
+}
+
+func TestPortStateToPB_RequiredFields(t *testing.T) {
+	state := states.NewTemperaturePortState(300)
+
+	_, err := PortStateToPB("tag", state, []string{temperatureField})
+	assert.Nil(t, err)
+
+	_, err = PortStateToPB("tag", state, []string{pressureField})
+	assert.Error(t, err)
+}
+
+func TestPortStateToPB_NilState(t *testing.T) {
+	_, err := PortStateToPB("tag", nil, nil)
+	assert.Error(t, err)
+}
+
+func TestPortStateFromPB_Invalid(t *testing.T) {
+	tc := []*pb.PortState{
+		nil,
+		{Tag: "tag"},
+		{State: &pb.State{NumValues: map[string]float64{"unknown": 1}}},
+		{State: &pb.State{StringValues: map[string]string{gasField: "unknown"}}},
+		{State: &pb.State{NumValues: map[string]float64{temperatureField: 1, pressureField: 1}}},
+	}
+
+	for i, c := range tc {
+		_, err := PortStateFromPB(c)
+		assert.Error(t, err, "case %d", i)
+	}
+}
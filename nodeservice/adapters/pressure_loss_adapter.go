@@ -0,0 +1,83 @@
+package adapters
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbonetwork/pb"
+)
+
+// names of pressure loss node parameters
+const (
+	sigmaParam = "sigma"
+)
+
+// NewPressureLossAdapter constructs NodeAdapter which handles PressureLossNodeType nodes
+// creation data must contain sigma in dKwargs
+func NewPressureLossAdapter() NodeAdapter {
+	return pressureLossAdapter{}
+}
+
+type pressureLossAdapter struct{}
+
+func (a pressureLossAdapter) Create(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+	sigma, err := getDKwarg(data, sigmaParam)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFraction(sigmaParam, sigma); err != nil {
+		return nil, err
+	}
+	return constructive.NewPressureLossNode(sigma), nil
+}
+
+func (a pressureLossAdapter) Update(node graph.Node, data *pb.RequestData) error {
+	plNode, err := a.cast(node)
+	if err != nil {
+		return err
+	}
+
+	sigma := getOptionalDKwarg(data, sigmaParam, plNode.Sigma())
+	if err := checkFraction(sigmaParam, sigma); err != nil {
+		return err
+	}
+	plNode.SetSigma(sigma)
+	return nil
+}
+
+func (a pressureLossAdapter) GetState(node graph.Node, requiredFields []string) (*pb.NodeState, error) {
+	plNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return getNodeState(
+		plNode,
+		map[string]float64{sigmaParam: plNode.Sigma()},
+		getGasChannelPorts(plNode),
+		requiredFields,
+	)
+}
+
+func (a pressureLossAdapter) GetPort(tag string, node graph.Node) (graph.Port, error) {
+	plNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+	return getPort(tag, getGasChannelPorts(plNode))
+}
+
+func (a pressureLossAdapter) GetDescription() *pb.NodeDescription {
+	return &pb.NodeDescription{
+		NodeType:  PressureLossNodeType,
+		BasePorts: getGasChannelPortDescriptions(),
+	}
+}
+
+func (a pressureLossAdapter) cast(node graph.Node) (constructive.PressureLossNode, error) {
+	plNode, ok := node.(constructive.PressureLossNode)
+	if !ok {
+		return nil, fmt.Errorf("node %s is not a pressure loss node", node.GetName())
+	}
+	return plNode, nil
+}
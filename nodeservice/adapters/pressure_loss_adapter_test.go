@@ -0,0 +1,55 @@
+package adapters
+
+import (
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type PressureLossAdapterTestSuite struct {
+	adapterTestSuite
+}
+
+func (s *PressureLossAdapterTestSuite) SetupTest() {
+	s.adapter = NewPressureLossAdapter()
+	s.data = getDKwargs(map[string]float64{sigmaParam: 0.95})
+}
+
+func (s *PressureLossAdapterTestSuite) TestCreate_OK() {
+	node := s.createNode()
+	s.InDelta(0.95, node.(constructive.PressureLossNode).Sigma(), 1e-9)
+}
+
+func (s *PressureLossAdapterTestSuite) TestCreate_InvalidArgs() {
+	s.checkCreateErrors(
+		&pb.RequestData{},
+		getDKwargs(map[string]float64{sigmaParam: 1.5}),
+	)
+}
+
+func (s *PressureLossAdapterTestSuite) TestUpdate() {
+	node := s.createNode()
+
+	s.Require().Nil(s.adapter.Update(node, getDKwargs(map[string]float64{sigmaParam: 0.9})))
+	s.InDelta(0.9, node.(constructive.PressureLossNode).Sigma(), 1e-9)
+
+	s.checkUpdateError(node, getDKwargs(map[string]float64{sigmaParam: 0}))
+}
+
+func (s *PressureLossAdapterTestSuite) TestGetState() {
+	node := s.createNode()
+	port, _ := s.adapter.GetPort(temperatureInput, node)
+	port.SetState(states.NewTemperaturePortState(300))
+
+	state, err := s.adapter.GetState(node, nil)
+	s.Require().Nil(err)
+	s.InDelta(0.95, state.State.NumValues[sigmaParam], 1e-9)
+	s.Require().Equal(1, len(state.PortStates))
+	s.Equal(temperatureInput, state.PortStates[0].Tag)
+}
+
+func TestPressureLossAdapterTestSuite(t *testing.T) {
+	suite.Run(t, new(PressureLossAdapterTestSuite))
+}
@@ -0,0 +1,162 @@
+package adapters
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbonetwork/pb"
+)
+
+// names of regenerator node parameters
+const (
+	effectivenessParam = "effectiveness"
+	hotSigmaParam      = "hotSigma"
+	coldSigmaParam     = "coldSigma"
+	heatDutyParam      = "heatDuty"
+)
+
+// prefixes of regenerator gas paths
+const (
+	hotPath  = "hot"
+	coldPath = "cold"
+)
+
+// NewRegeneratorAdapter constructs NodeAdapter which handles RegeneratorNodeType nodes
+// creation data must contain effectiveness, hotSigma and coldSigma (pressure recovery factors of
+// hot and cold paths) in dKwargs. Ports of hot and cold paths are prefixed with hot_ and cold_ respectively
+func NewRegeneratorAdapter() NodeAdapter {
+	return regeneratorAdapter{}
+}
+
+type regeneratorAdapter struct{}
+
+func (a regeneratorAdapter) Create(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+	params := make(map[string]float64)
+	for _, name := range []string{effectivenessParam, hotSigmaParam, coldSigmaParam} {
+		val, err := getDKwarg(data, name)
+		if err != nil {
+			return nil, err
+		}
+		params[name] = val
+	}
+
+	if err := a.checkParams(params); err != nil {
+		return nil, err
+	}
+	return constructive.NewRegeneratorNode(
+		params[effectivenessParam], params[hotSigmaParam], params[coldSigmaParam],
+		getOptionalDKwarg(data, precisionParam, defaultPrecision),
+	), nil
+}
+
+func (a regeneratorAdapter) Update(node graph.Node, data *pb.RequestData) error {
+	rNode, err := a.cast(node)
+	if err != nil {
+		return err
+	}
+
+	params := map[string]float64{
+		effectivenessParam: getOptionalDKwarg(data, effectivenessParam, rNode.Sigma()),
+		hotSigmaParam:      getOptionalDKwarg(data, hotSigmaParam, rNode.HotPressureSigma()),
+		coldSigmaParam:     getOptionalDKwarg(data, coldSigmaParam, rNode.ColdPressureSigma()),
+	}
+	if err := a.checkParams(params); err != nil {
+		return err
+	}
+	rNode.SetSigma(params[effectivenessParam])
+	rNode.SetHotPressureSigma(params[hotSigmaParam])
+	rNode.SetColdPressureSigma(params[coldSigmaParam])
+	return nil
+}
+
+func (a regeneratorAdapter) GetState(node graph.Node, requiredFields []string) (*pb.NodeState, error) {
+	rNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]float64{
+		effectivenessParam: rNode.Sigma(),
+		hotSigmaParam:      rNode.HotPressureSigma(),
+		coldSigmaParam:     rNode.ColdPressureSigma(),
+	}
+	// heat duty is known only after node has been processed
+	if heatDuty, ok := a.getHeatDuty(rNode); ok {
+		values[heatDutyParam] = heatDuty
+	}
+
+	return getNodeState(rNode, values, a.getPorts(rNode), requiredFields)
+}
+
+func (a regeneratorAdapter) GetPort(tag string, node graph.Node) (graph.Port, error) {
+	rNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+	return getPort(tag, a.getPorts(rNode))
+}
+
+func (a regeneratorAdapter) GetDescription() *pb.NodeDescription {
+	return &pb.NodeDescription{
+		NodeType: RegeneratorNodeType,
+		BasePorts: append(
+			getPrefixedPortDescriptions(hotPath, getGasChannelPortDescriptions()),
+			getPrefixedPortDescriptions(coldPath, getGasChannelPortDescriptions())...,
+		),
+	}
+}
+
+// getHeatDuty calculates heat flow transferred to the cold path
+func (a regeneratorAdapter) getHeatDuty(node constructive.RegeneratorNode) (float64, bool) {
+	tIn, ok := node.ColdInput().TemperatureInput().GetState().(states.TemperaturePortState)
+	if !ok {
+		return 0, false
+	}
+	tOut, ok := node.ColdOutput().TemperatureOutput().GetState().(states.TemperaturePortState)
+	if !ok {
+		return 0, false
+	}
+	massRate, ok := node.ColdInput().MassRateInput().GetState().(states.MassRatePortState)
+	if !ok {
+		return 0, false
+	}
+	gas, ok := node.ColdInput().GasInput().GetState().(states.GasPortState)
+	if !ok {
+		return 0, false
+	}
+
+	cp := gas.Gas.Cp((tIn.TStag + tOut.TStag) / 2)
+	return massRate.MassRate * cp * (tOut.TStag - tIn.TStag), true
+}
+
+func (a regeneratorAdapter) getPorts(node constructive.RegeneratorNode) map[string]graph.Port {
+	result := getPrefixedPorts(hotPath, getGasSinkPorts(node.HotInput()))
+	for _, ports := range []map[string]graph.Port{
+		getPrefixedPorts(hotPath, getGasSourcePorts(node.HotOutput())),
+		getPrefixedPorts(coldPath, getGasSinkPorts(node.ColdInput())),
+		getPrefixedPorts(coldPath, getGasSourcePorts(node.ColdOutput())),
+	} {
+		for tag, port := range ports {
+			result[tag] = port
+		}
+	}
+	return result
+}
+
+func (a regeneratorAdapter) checkParams(params map[string]float64) error {
+	for _, name := range []string{effectivenessParam, hotSigmaParam, coldSigmaParam} {
+		if err := checkFraction(name, params[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a regeneratorAdapter) cast(node graph.Node) (constructive.RegeneratorNode, error) {
+	rNode, ok := node.(constructive.RegeneratorNode)
+	if !ok {
+		return nil, fmt.Errorf("node %s is not a regenerator node", node.GetName())
+	}
+	return rNode, nil
+}
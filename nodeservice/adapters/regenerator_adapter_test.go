@@ -0,0 +1,116 @@
+package adapters
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbocycle/material/gases"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type RegeneratorAdapterTestSuite struct {
+	adapterTestSuite
+}
+
+func (s *RegeneratorAdapterTestSuite) SetupTest() {
+	s.adapter = NewRegeneratorAdapter()
+	s.data = s.getData(0.8, 0.97, 0.98)
+}
+
+func (s *RegeneratorAdapterTestSuite) TestCreate_OK() {
+	rNode := s.createNode().(constructive.RegeneratorNode)
+	s.InDelta(0.8, rNode.Sigma(), 1e-9)
+	s.InDelta(0.97, rNode.HotPressureSigma(), 1e-9)
+	s.InDelta(0.98, rNode.ColdPressureSigma(), 1e-9)
+}
+
+func (s *RegeneratorAdapterTestSuite) TestCreate_InvalidArgs() {
+	s.checkCreateErrors(
+		nil,
+		getDKwargs(map[string]float64{effectivenessParam: 0.8, hotSigmaParam: 0.97}),
+		s.getData(0, 0.97, 0.98),
+		s.getData(0.8, 1.1, 0.98),
+		s.getData(0.8, 0.97, -1),
+	)
+}
+
+func (s *RegeneratorAdapterTestSuite) TestUpdate() {
+	node := s.createNode()
+
+	s.Require().Nil(s.adapter.Update(node, getDKwargs(map[string]float64{effectivenessParam: 0.7})))
+
+	rNode := node.(constructive.RegeneratorNode)
+	s.InDelta(0.7, rNode.Sigma(), 1e-9)
+	s.InDelta(0.97, rNode.HotPressureSigma(), 1e-9)
+
+	s.checkUpdateError(node, s.getData(0.8, 0.97, 2))
+	s.InDelta(0.98, rNode.ColdPressureSigma(), 1e-9)
+}
+
+func (s *RegeneratorAdapterTestSuite) TestGetPort() {
+	node := s.createNode()
+	rNode := node.(constructive.RegeneratorNode)
+
+	hotPort, err := s.adapter.GetPort("hot_temperature_input", node)
+	s.Require().Nil(err)
+	s.Equal(rNode.HotInput().TemperatureInput(), hotPort)
+
+	coldPort, err := s.adapter.GetPort("cold_temperature_input", node)
+	s.Require().Nil(err)
+	s.Equal(rNode.ColdInput().TemperatureInput(), coldPort)
+	s.False(hotPort == coldPort)
+
+	_, err = s.adapter.GetPort(temperatureInput, node)
+	s.Error(err)
+}
+
+func (s *RegeneratorAdapterTestSuite) TestGetState() {
+	node := s.createNode()
+	rNode := node.(constructive.RegeneratorNode)
+
+	state, err := s.adapter.GetState(node, nil)
+	s.Require().Nil(err)
+	_, ok := state.State.NumValues[heatDutyParam]
+	s.False(ok)
+
+	s.setInputs(rNode.HotInput().TemperatureInput(), rNode.HotInput().PressureInput(),
+		rNode.HotInput().MassRateInput(), rNode.HotInput().GasInput(), 800)
+	s.setInputs(rNode.ColdInput().TemperatureInput(), rNode.ColdInput().PressureInput(),
+		rNode.ColdInput().MassRateInput(), rNode.ColdInput().GasInput(), 500)
+	s.Require().Nil(rNode.Process())
+
+	state, err = s.adapter.GetState(node, []string{heatDutyParam})
+	s.Require().Nil(err)
+	s.Equal(1, len(state.State.NumValues))
+
+	tOut := rNode.ColdOutput().TemperatureOutput().GetState().(states.TemperaturePortState).TStag
+	expected := gases.GetAir().Cp((500+tOut)/2) * (tOut - 500)
+	s.InDelta(expected, state.State.NumValues[heatDutyParam], 1e-6)
+	s.True(state.State.NumValues[heatDutyParam] > 0)
+	s.Equal(16, len(state.PortStates))
+}
+
+func (s *RegeneratorAdapterTestSuite) TestDescription_HotAndColdPaths() {
+	s.Equal(16, len(s.adapter.GetDescription().BasePorts))
+}
+
+func (s *RegeneratorAdapterTestSuite) setInputs(t, p, m, g graph.Port, tStag float64) {
+	t.SetState(states.NewTemperaturePortState(tStag))
+	p.SetState(states.NewPressurePortState(1e5))
+	m.SetState(states.NewMassRatePortState(1))
+	g.SetState(states.NewGasPortState(gases.GetAir()))
+}
+
+func (s *RegeneratorAdapterTestSuite) getData(effectiveness, hotSigma, coldSigma float64) *pb.RequestData {
+	return getDKwargs(map[string]float64{
+		effectivenessParam: effectiveness,
+		hotSigmaParam:      hotSigma,
+		coldSigmaParam:     coldSigma,
+	})
+}
+
+func TestRegeneratorAdapterTestSuite(t *testing.T) {
+	suite.Run(t, new(RegeneratorAdapterTestSuite))
+}
@@ -0,0 +1,113 @@
+package adapters
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/pb"
+)
+
+// names of shaft node parameters
+const (
+	etaMParam  = "etaM"
+	powerParam = "power"
+)
+
+// NewShaftAdapter constructs NodeAdapter which handles ShaftNodeType nodes
+// creation data must contain etaM in dKwargs. Number of power inputs is taken from
+// cardinality of power_input multiport, inputs are tagged power_input_1 ... power_input_N
+func NewShaftAdapter() NodeAdapter {
+	return shaftAdapter{}
+}
+
+type shaftAdapter struct{}
+
+func (a shaftAdapter) Create(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+	etaM, err := getDKwarg(data, etaMParam)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFraction(etaMParam, etaM); err != nil {
+		return nil, err
+	}
+
+	inputNum, ok := multiPorts[powerInput]
+	if !ok {
+		return nil, common.NewError(badRequest, "number of ports of multiport %s not specified", powerInput)
+	}
+	if inputNum <= 0 {
+		return nil, common.NewError(badRequest, "number of ports of multiport %s must be positive (got %d)", powerInput, inputNum)
+	}
+	return constructive.NewShaftNode(etaM, int(inputNum)), nil
+}
+
+func (a shaftAdapter) Update(node graph.Node, data *pb.RequestData) error {
+	sNode, err := a.cast(node)
+	if err != nil {
+		return err
+	}
+
+	etaM := getOptionalDKwarg(data, etaMParam, sNode.EtaM())
+	if err := checkFraction(etaMParam, etaM); err != nil {
+		return err
+	}
+	sNode.SetEtaM(etaM)
+	return nil
+}
+
+func (a shaftAdapter) GetState(node graph.Node, requiredFields []string) (*pb.NodeState, error) {
+	sNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return getNodeState(
+		sNode,
+		map[string]float64{
+			etaMParam:  sNode.EtaM(),
+			powerParam: sNode.Power(),
+		},
+		a.getPorts(sNode),
+		requiredFields,
+	)
+}
+
+func (a shaftAdapter) GetPort(tag string, node graph.Node) (graph.Port, error) {
+	sNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+	return getPort(tag, a.getPorts(sNode))
+}
+
+func (a shaftAdapter) GetDescription() *pb.NodeDescription {
+	return &pb.NodeDescription{
+		NodeType: ShaftNodeType,
+		BasePorts: []*pb.NodeDescription_AttachedPortDescription{
+			{
+				Description: &pb.PortDescription{Prefix: powerInput, IsMulti: true},
+				Type:        pb.NodeDescription_AttachedPortDescription_INPUT,
+			},
+			getPortDescription(powerOutput, pb.NodeDescription_AttachedPortDescription_OUTPUT),
+		},
+	}
+}
+
+func (a shaftAdapter) getPorts(node constructive.ShaftNode) map[string]graph.Port {
+	result := map[string]graph.Port{
+		powerOutput: node.PowerOutput(),
+	}
+	for i, port := range node.PowerInputs() {
+		result[common.GetMultiPortTag(powerInput, i+1)] = port
+	}
+	return result
+}
+
+func (a shaftAdapter) cast(node graph.Node) (constructive.ShaftNode, error) {
+	sNode, ok := node.(constructive.ShaftNode)
+	if !ok {
+		return nil, fmt.Errorf("node %s is not a shaft node", node.GetName())
+	}
+	return sNode, nil
+}
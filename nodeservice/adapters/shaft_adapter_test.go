@@ -0,0 +1,87 @@
+package adapters
+
+import (
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ShaftAdapterTestSuite struct {
+	adapterTestSuite
+}
+
+func (s *ShaftAdapterTestSuite) SetupTest() {
+	s.adapter = NewShaftAdapter()
+	s.data = s.getData(0.99)
+	s.multiPorts = map[string]int32{powerInput: 3}
+}
+
+func (s *ShaftAdapterTestSuite) TestCreate_OK() {
+	sNode := s.createNode().(constructive.ShaftNode)
+	s.InDelta(0.99, sNode.EtaM(), 1e-9)
+	s.Equal(3, len(sNode.PowerInputs()))
+}
+
+func (s *ShaftAdapterTestSuite) TestCreate_InvalidArgs() {
+	tc := []struct {
+		data       *pb.RequestData
+		multiPorts map[string]int32
+	}{
+		{nil, map[string]int32{powerInput: 2}},
+		{s.getData(1.2), map[string]int32{powerInput: 2}},
+		{s.getData(0.99), nil},
+		{s.getData(0.99), map[string]int32{powerInput: 0}},
+	}
+
+	for i, c := range tc {
+		_, err := s.adapter.Create(c.data, c.multiPorts)
+		s.Error(err, "case %d", i)
+	}
+}
+
+func (s *ShaftAdapterTestSuite) TestUpdate() {
+	node := s.createNode()
+
+	s.Require().Nil(s.adapter.Update(node, s.getData(0.98)))
+	s.InDelta(0.98, node.(constructive.ShaftNode).EtaM(), 1e-9)
+
+	s.checkUpdateError(node, s.getData(0))
+}
+
+func (s *ShaftAdapterTestSuite) TestGetPort() {
+	node := s.createNode()
+	sNode := node.(constructive.ShaftNode)
+
+	port, err := s.adapter.GetPort("power_input_2", node)
+	s.Require().Nil(err)
+	s.Equal(sNode.PowerInputs()[1], port)
+
+	_, err = s.adapter.GetPort("power_input_4", node)
+	s.Error(err)
+	_, err = s.adapter.GetPort(powerInput, node)
+	s.Error(err)
+}
+
+func (s *ShaftAdapterTestSuite) TestGetState() {
+	node, _ := s.adapter.Create(s.getData(0.99), map[string]int32{powerInput: 2})
+	sNode := node.(constructive.ShaftNode)
+	sNode.PowerInputs()[0].SetState(states.NewPowerPortState(100))
+	sNode.PowerInputs()[1].SetState(states.NewPowerPortState(200))
+	s.Require().Nil(sNode.Process())
+
+	state, err := s.adapter.GetState(node, nil)
+	s.Require().Nil(err)
+	s.InDelta(0.99, state.State.NumValues[etaMParam], 1e-9)
+	s.InDelta(sNode.Power(), state.State.NumValues[powerParam], 1e-9)
+	s.Equal(3, len(state.PortStates))
+}
+
+func (s *ShaftAdapterTestSuite) getData(etaM float64) *pb.RequestData {
+	return getDKwargs(map[string]float64{etaMParam: etaM})
+}
+
+func TestShaftAdapterTestSuite(t *testing.T) {
+	suite.Run(t, new(ShaftAdapterTestSuite))
+}
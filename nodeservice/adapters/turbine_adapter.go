@@ -0,0 +1,151 @@
+package adapters
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/pb"
+)
+
+// names of turbine node parameters
+const (
+	piTParam       = "piT"
+	lambdaOutParam = "lambdaOut"
+	modeParam      = "mode"
+)
+
+const defaultLambdaOut = 0.3
+
+// turbine modes. Order of modes matches order of context states in turbine description
+const (
+	// blockedMode is a mode of turbine which drives compressor. Its power port is an input
+	blockedMode = "blocked"
+	// freeMode is a mode of turbine which drives external load. Its power port is an output
+	freeMode = "free"
+)
+
+// NewTurbineAdapter constructs NodeAdapter which handles TurbineNodeType nodes
+// creation data must contain eta in dKwargs and mode (blocked or free) in sKwargs.
+// Mode corresponds to the context state of the power port selected by the network
+func NewTurbineAdapter() NodeAdapter {
+	return turbineAdapter{}
+}
+
+type turbineAdapter struct{}
+
+func (a turbineAdapter) Create(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+	eta, err := getDKwarg(data, etaParam)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFraction(etaParam, eta); err != nil {
+		return nil, err
+	}
+
+	lambdaOut := getOptionalDKwarg(data, lambdaOutParam, defaultLambdaOut)
+	precision := getOptionalDKwarg(data, precisionParam, defaultPrecision)
+
+	switch mode := data.SKwargs[modeParam]; mode {
+	case blockedMode:
+		return constructive.NewBlockedTurbineNode(eta, lambdaOut, precision), nil
+	case freeMode:
+		return constructive.NewFreeTurbineNode(eta, lambdaOut, precision), nil
+	default:
+		return nil, common.NewError(badRequest, "invalid turbine mode \"%s\" (expected %s or %s)", mode, blockedMode, freeMode)
+	}
+}
+
+func (a turbineAdapter) Update(node graph.Node, data *pb.RequestData) error {
+	tNode, err := a.cast(node)
+	if err != nil {
+		return err
+	}
+
+	if mode, ok := data.GetSKwargs()[modeParam]; ok && mode != a.getMode(tNode) {
+		return common.NewError(badRequest, "turbine mode can not be changed after creation")
+	}
+
+	eta := getOptionalDKwarg(data, etaParam, tNode.Eta())
+	if err := checkFraction(etaParam, eta); err != nil {
+		return err
+	}
+	tNode.SetEta(eta)
+	return nil
+}
+
+func (a turbineAdapter) GetState(node graph.Node, requiredFields []string) (*pb.NodeState, error) {
+	tNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return getNodeState(
+		tNode,
+		map[string]float64{
+			piTParam:       tNode.PiTStag(),
+			etaParam:       tNode.Eta(),
+			lSpecificParam: tNode.LSpecific(),
+		},
+		a.getPorts(tNode),
+		requiredFields,
+	)
+}
+
+func (a turbineAdapter) GetPort(tag string, node graph.Node) (graph.Port, error) {
+	tNode, err := a.cast(node)
+	if err != nil {
+		return nil, err
+	}
+	return getPort(tag, a.getPorts(tNode))
+}
+
+func (a turbineAdapter) GetDescription() *pb.NodeDescription {
+	return &pb.NodeDescription{
+		NodeType: TurbineNodeType,
+		BasePorts: append(
+			getGasChannelPortDescriptions(),
+			getPortDescription(power, pb.NodeDescription_AttachedPortDescription_CONTEXT_DEPENDENT),
+		),
+		ContextStates: []*pb.NodeDescription_ContextState{
+			// blocked turbine consumes power demanded by compressor
+			{
+				Ports: []*pb.NodeDescription_AttachedPortDescription{
+					getPortDescription(power, pb.NodeDescription_AttachedPortDescription_INPUT),
+				},
+			},
+			// free turbine provides power to external load
+			{
+				Ports: []*pb.NodeDescription_AttachedPortDescription{
+					getPortDescription(power, pb.NodeDescription_AttachedPortDescription_OUTPUT),
+				},
+			},
+		},
+	}
+}
+
+func (a turbineAdapter) getPorts(node constructive.TurbineNode) map[string]graph.Port {
+	result := getGasChannelPorts(node)
+	switch n := node.(type) {
+	case constructive.BlockedTurbineNode:
+		result[power] = n.PowerInput()
+	case constructive.FreeTurbineNode:
+		result[power] = n.PowerOutput()
+	}
+	return result
+}
+
+func (a turbineAdapter) getMode(node constructive.TurbineNode) string {
+	if _, ok := node.(constructive.FreeTurbineNode); ok {
+		return freeMode
+	}
+	return blockedMode
+}
+
+func (a turbineAdapter) cast(node graph.Node) (constructive.TurbineNode, error) {
+	tNode, ok := node.(constructive.TurbineNode)
+	if !ok {
+		return nil, fmt.Errorf("node %s is not a turbine node", node.GetName())
+	}
+	return tNode, nil
+}
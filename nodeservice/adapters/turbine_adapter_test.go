@@ -0,0 +1,68 @@
+package adapters
+
+import (
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type TurbineAdapterTestSuite struct {
+	adapterTestSuite
+}
+
+func (s *TurbineAdapterTestSuite) SetupTest() {
+	s.adapter = NewTurbineAdapter()
+	s.data = s.getData(blockedMode)
+}
+
+func (s *TurbineAdapterTestSuite) TestCreate_Blocked() {
+	node := s.createNode()
+
+	tNode, ok := node.(constructive.BlockedTurbineNode)
+	s.Require().True(ok)
+	s.InDelta(0.9, tNode.Eta(), 1e-9)
+
+	port, err := s.adapter.GetPort(power, node)
+	s.Require().Nil(err)
+	s.Equal(tNode.PowerInput(), port)
+}
+
+func (s *TurbineAdapterTestSuite) TestCreate_Free() {
+	node, err := s.adapter.Create(s.getData(freeMode), nil)
+	s.Require().Nil(err)
+
+	tNode, ok := node.(constructive.FreeTurbineNode)
+	s.Require().True(ok)
+
+	port, err := s.adapter.GetPort(power, node)
+	s.Require().Nil(err)
+	s.Equal(tNode.PowerOutput(), port)
+
+	// description is the same for both modes
+	checkDescription(s.T(), s.adapter, node, nil)
+}
+
+func (s *TurbineAdapterTestSuite) TestCreate_InvalidMode() {
+	s.checkCreateErrors(s.getData("unknown"))
+}
+
+func (s *TurbineAdapterTestSuite) TestUpdate() {
+	node := s.createNode()
+
+	s.Require().Nil(s.adapter.Update(node, getDKwargs(map[string]float64{etaParam: 0.88})))
+	s.InDelta(0.88, node.(constructive.TurbineNode).Eta(), 1e-9)
+
+	s.checkUpdateError(node, &pb.RequestData{SKwargs: map[string]string{modeParam: freeMode}})
+}
+
+func (s *TurbineAdapterTestSuite) getData(mode string) *pb.RequestData {
+	return &pb.RequestData{
+		DKwargs: map[string]float64{etaParam: 0.9},
+		SKwargs: map[string]string{modeParam: mode},
+	}
+}
+
+func TestTurbineAdapterTestSuite(t *testing.T) {
+	suite.Run(t, new(TurbineAdapterTestSuite))
+}
@@ -1,6 +1,9 @@
 package adapters
 
-import "github.com/Sovianum/turbocycle/core/graph"
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbonetwork/pb"
+)
 
 // NewTypedNode constructs Typed node out of its components
 func NewTypedNode(node graph.Node, nodeType string) *TypedNode {
@@ -11,7 +14,63 @@ func NewTypedNode(node graph.Node, nodeType string) *TypedNode {
 }
 
 // TypedNode is a helper struct combining Node with its type tag
+// and data necessary to construct the node once again
 type TypedNode struct {
 	NodeType string
 	Node     graph.Node
+
+	// CreateData and MultiPorts are the arguments node was created with
+	CreateData *pb.RequestData
+	MultiPorts map[string]int32
+	// Updates are arguments of all the updates applied to the node in order of application.
+	// They are not merged because parameters of different updates may be invalid together
+	Updates []*pb.RequestData
+	// WeakPorts are weak wrappers of the node ports by port tag. Weakness of the link
+	// can not be read from the port, so it is told by the wrapper the linked port refers to
+	WeakPorts map[string]graph.Port
+}
+
+// NewWeakPort wraps port of the node with tag to weak port and remembers the wrapper
+func (n *TypedNode) NewWeakPort(tag string, port graph.Port) graph.Port {
+	if n.WeakPorts == nil {
+		n.WeakPorts = make(map[string]graph.Port)
+	}
+	weak := graph.NewWeakPort(port)
+	n.WeakPorts[tag] = weak
+	return weak
+}
+
+// AddUpdate appends data to the Updates of the node. Updates are replaced rather than modified,
+// so previously returned Updates stay intact
+func (n *TypedNode) AddUpdate(data *pb.RequestData) {
+	if data == nil {
+		return
+	}
+	updates := make([]*pb.RequestData, len(n.Updates), len(n.Updates)+1)
+	copy(updates, n.Updates)
+	n.Updates = append(updates, data)
+}
+
+// GetAppliedData returns CreateData merged with Updates, that is the last values
+// of all the arguments passed to the node
+func (n *TypedNode) GetAppliedData() *pb.RequestData {
+	return mergeRequestData(append([]*pb.RequestData{n.CreateData}, n.Updates...)...)
+}
+
+// mergeRequestData returns new request data with arguments of all the items,
+// values of the latter items override the former ones
+func mergeRequestData(items ...*pb.RequestData) *pb.RequestData {
+	result := &pb.RequestData{
+		DKwargs: make(map[string]float64),
+		SKwargs: make(map[string]string),
+	}
+	for _, d := range items {
+		for key, val := range d.GetDKwargs() {
+			result.DKwargs[key] = val
+		}
+		for key, val := range d.GetSKwargs() {
+			result.SKwargs[key] = val
+		}
+	}
+	return result
 }
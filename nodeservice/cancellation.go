@@ -0,0 +1,39 @@
+package nodeservice
+
+import (
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/pb"
+	"golang.org/x/net/context"
+)
+
+// getContextErr returns error of the done context. Nil context is never done
+func getContextErr(c context.Context) error {
+	if c == nil {
+		return nil
+	}
+	return c.Err()
+}
+
+// getContextError converts error of the done context to Error with the corresponding code,
+// so that client can distinguish cancellation and deadline from server failures
+func getContextError(err error) *common.Error {
+	if err == context.DeadlineExceeded {
+		return common.NewError(deadlineExceeded, "%v", err)
+	}
+	return common.NewError(cancelled, "%v", err)
+}
+
+// cancelModify finishes modifying batch request whose context is done before all its items are handled.
+// Applied items of atomic request are reverted, applied items of other requests are kept.
+// Items are not reported because gRPC drops response of the failed request, status error is returned instead
+func cancelModify(atomic bool, log *rollbackLog, ctxErr error) (*pb.NodeModifyResponse, error) {
+	reqErr := getContextError(ctxErr)
+	if !atomic {
+		return getModifyErrResponse(reqErr), reqErr
+	}
+	if errList := log.rollback(); errList != nil {
+		rollbackErr := common.NewError(internalError, "%s, rollback failed: %s", reqErr.Msg, joinErrors(errList))
+		return getModifyErrResponse(rollbackErr), rollbackErr
+	}
+	return getModifyRolledBackResponse(nil, reqErr.Msg, reqErr.Code), reqErr
+}
@@ -0,0 +1,54 @@
+package nodeservice
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestGetContextError(t *testing.T) {
+	assert.Equal(t, codes.Canceled, status.Code(getContextError(context.Canceled)))
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(getContextError(context.DeadlineExceeded)))
+	assert.Equal(t, deadlineExceeded, getContextError(context.DeadlineExceeded).Code)
+}
+
+func TestCancelModify(t *testing.T) {
+	reverted := false
+	log := &rollbackLog{}
+	log.add(func() error {
+		reverted = true
+		return nil
+	})
+
+	resp, err := cancelModify(false, log, context.Canceled)
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	assert.EqualValues(t, cancelled, resp.Base.Status)
+	assert.False(t, resp.RolledBack)
+	assert.False(t, reverted)
+
+	resp, err = cancelModify(true, log, context.DeadlineExceeded)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.EqualValues(t, deadlineExceeded, resp.Base.Status)
+	assert.True(t, resp.RolledBack)
+	assert.True(t, reverted)
+
+	log.add(func() error {
+		return fmt.Errorf("revert error")
+	})
+	resp, err = cancelModify(true, log, context.Canceled)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Contains(t, err.Error(), "revert error")
+	assert.False(t, resp.RolledBack)
+}
+
+func TestGetContextErr(t *testing.T) {
+	assert.Nil(t, getContextErr(nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.Nil(t, getContextErr(ctx))
+	cancel()
+	assert.Equal(t, context.Canceled, getContextErr(ctx))
+}
@@ -1,7 +1,14 @@
 package nodeservice
 
+import "github.com/Sovianum/turbonetwork/pb"
+
 const (
-	internalError = 500 // internalError is an analog of HTTP_INTERNAL_ERROR
-	ok            = 200 // ok is an analog of HTTP_OK
-	notFound      = 404 // notFound is an analog of HTTP_NOT_FOUND
+	internalError    = pb.StatusCode_INTERNAL_ERROR    // internalError is an analog of HTTP_INTERNAL_ERROR
+	ok               = pb.StatusCode_OK                // ok is an analog of HTTP_OK
+	notFound         = pb.StatusCode_NOT_FOUND         // notFound is an analog of HTTP_NOT_FOUND
+	badRequest       = pb.StatusCode_BAD_REQUEST       // badRequest is an analog of HTTP_BAD_REQUEST
+	failedDependency = pb.StatusCode_FAILED_DEPENDENCY // failedDependency is an analog of HTTP_FAILED_DEPENDENCY (item reverted by atomic request)
+	conflict         = pb.StatusCode_CONFLICT          // conflict is an analog of HTTP_CONFLICT (node name is already used in the session)
+	cancelled        = pb.StatusCode_CANCELLED         // cancelled is an analog of CLIENT_CLOSED_REQUEST (request context is done before the item is handled)
+	deadlineExceeded = pb.StatusCode_DEADLINE_EXCEEDED // deadlineExceeded is an analog of HTTP_GATEWAY_TIMEOUT (request deadline is exceeded before the item is handled)
 )
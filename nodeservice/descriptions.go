@@ -5,8 +5,17 @@ import (
 	"github.com/Sovianum/turbonetwork/pb"
 )
 
-var nodeDescriptionList = []*pb.NodeDescription{
-	{
-		NodeType: adapters.PressureLossNodeType,
-	},
+// getNodeDescriptions collects descriptions of all the node types supported by factory
+func getNodeDescriptions(factory adapters.NodeAdapterFactory) ([]*pb.NodeDescription, error) {
+	nodeTypes := factory.GetNodeTypes()
+	result := make([]*pb.NodeDescription, len(nodeTypes))
+
+	for i, nodeType := range nodeTypes {
+		adapter, err := factory.GetAdapter(nodeType)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = adapter.GetDescription()
+	}
+	return result, nil
 }
@@ -0,0 +1,285 @@
+package nodeservice
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/nodeservice/adapters"
+	"github.com/Sovianum/turbonetwork/pb"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// PersistentNodeStorage is a NodeStorage which can save its content to survive restarts
+type PersistentNodeStorage interface {
+	NodeStorage
+	// Snapshot saves nodes and links between them
+	Snapshot() error
+}
+
+// NewFileNodeStorage constructs PersistentNodeStorage which saves snapshots to the file at path.
+// If the file exists, nodes and links saved in it are rebuilt through the adapters of factory
+// under the same identifiers. If factory is nil, registry with all builtin adapters is used.
+// Port states are not saved
+func NewFileNodeStorage(path string, factory adapters.NodeAdapterFactory) (PersistentNodeStorage, error) {
+	if factory == nil {
+		factory = adapters.NewDefaultNodeAdapterRegistry()
+	}
+
+	result := &fileNodeStorage{
+		mapNodeStorage: NewMapNodeStorage().(*mapNodeStorage),
+		path:           path,
+		factory:        factory,
+		snapshotLock:   sync.Mutex{},
+	}
+	if err := result.restore(); err != nil {
+		return nil, fmt.Errorf("failed to restore storage from %s: %s", path, err.Error())
+	}
+	return result, nil
+}
+
+type storageSnapshot struct {
+	IDCounters map[string]int32 `json:"idCounters"`
+	Nodes      []nodeRecord     `json:"nodes"`
+	Links      []linkRecord     `json:"links"`
+}
+
+type nodeRecord struct {
+	ID         *pb.NodeIdentifier `json:"id"`
+	Name       string             `json:"name"`
+	CreateData *pb.RequestData    `json:"createData"`
+	MultiPorts map[string]int32   `json:"multiPorts,omitempty"`
+	Updates    []*pb.RequestData  `json:"updates,omitempty"`
+}
+
+type linkRecord struct {
+	Port1    *pb.PortIdentifier `json:"port1"`
+	Port2    *pb.PortIdentifier `json:"port2"`
+	LinkType pb.LinkType        `json:"linkType,omitempty"`
+}
+
+type fileNodeStorage struct {
+	*mapNodeStorage
+
+	path         string
+	factory      adapters.NodeAdapterFactory
+	snapshotLock sync.Mutex
+}
+
+func (s *fileNodeStorage) Snapshot() error {
+	s.snapshotLock.Lock()
+	defer s.snapshotLock.Unlock()
+
+	snapshot, err := s.getSnapshot()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	// snapshot is written to temporary file first so that crash during write does not spoil previous one
+	tmpPath := s.path + ".tmp"
+	if err := writeFileSync(tmpPath, data); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, s.path)
+}
+
+// writeFileSync writes data to the file at path and flushes it to the disk,
+// so that the file is complete when it is renamed
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (s *fileNodeStorage) getSnapshot() (*storageSnapshot, error) {
+	result := &storageSnapshot{
+		IDCounters: s.getIDCounters(),
+		Nodes:      make([]nodeRecord, 0),
+		Links:      make([]linkRecord, 0),
+	}
+
+	var ports []graph.Port
+	portIndex := make(map[graph.Port]*pb.PortIdentifier)
+	// weak wrappers are indexed as the ports they wrap
+	basePorts := make(map[graph.Port]graph.Port)
+	weakPorts := make(map[graph.Port]bool)
+
+	for _, id := range s.getAllIDs() {
+		node, err := s.Get(id)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Nodes = append(result.Nodes, nodeRecord{
+			ID:         id,
+			Name:       node.Node.GetInstanceName(),
+			CreateData: node.CreateData,
+			MultiPorts: node.MultiPorts,
+			Updates:    node.Updates,
+		})
+
+		adapter, err := s.factory.GetAdapter(id.NodeType)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range getPortTags(adapter.GetDescription(), node.MultiPorts) {
+			port, err := adapter.GetPort(tag, node.Node)
+			if err != nil {
+				return nil, err
+			}
+			portID := &pb.PortIdentifier{NodeIdentifier: id, PortTag: tag}
+			ports = append(ports, port)
+			portIndex[port] = portID
+			basePorts[port] = port
+			if weak, ok := node.WeakPorts[tag]; ok {
+				portIndex[weak] = portID
+				basePorts[weak] = port
+				weakPorts[weak] = true
+			}
+		}
+	}
+
+	// every link is seen from both its ports, but it must be saved only once
+	seen := make(map[graph.Port]bool)
+	for _, port := range ports {
+		link := port.GetLinkPort()
+		linkID, ok := portIndex[link]
+		if !ok || seen[port] {
+			continue
+		}
+		linkBase := basePorts[link]
+		seen[port] = true
+		seen[linkBase] = true
+
+		// port is weak if the linked port refers to its weak wrapper
+		back := linkBase.GetLinkPort()
+		weak := weakPorts[back] && basePorts[back] == port
+		result.Links = append(result.Links, linkRecord{
+			Port1:    portIndex[port],
+			Port2:    linkID,
+			LinkType: getLinkType(weak, weakPorts[link]),
+		})
+	}
+
+	return result, nil
+}
+
+func (s *fileNodeStorage) restore() error {
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	snapshot := &storageSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return err
+	}
+
+	for _, record := range snapshot.Nodes {
+		if err := s.restoreNode(record); err != nil {
+			return fmt.Errorf("failed to restore node %v: %s", record.ID, err.Error())
+		}
+	}
+
+	for _, record := range snapshot.Links {
+		ref1, err := s.getPortRef(record.Port1)
+		if err != nil {
+			return fmt.Errorf("failed to restore link: %s", err.Error())
+		}
+		ref2, err := s.getPortRef(record.Port2)
+		if err != nil {
+			return fmt.Errorf("failed to restore link: %s", err.Error())
+		}
+		linkPorts(ref1, ref2, record.LinkType)
+	}
+
+	for session, cnt := range snapshot.IDCounters {
+		s.setIDCounter(session, cnt)
+	}
+	return nil
+}
+
+func (s *fileNodeStorage) restoreNode(record nodeRecord) error {
+	if record.ID == nil {
+		return fmt.Errorf("node identifier not set")
+	}
+
+	adapter, err := s.factory.GetAdapter(record.ID.NodeType)
+	if err != nil {
+		return err
+	}
+
+	node, err := adapter.Create(record.CreateData, record.MultiPorts)
+	if err != nil {
+		return err
+	}
+	node.SetName(record.Name)
+
+	// updates are replayed one by one because they may be invalid together
+	for i, update := range record.Updates {
+		if err := adapter.Update(node, update); err != nil {
+			return fmt.Errorf("failed to replay update %d: %s", i, err.Error())
+		}
+	}
+
+	typedNode := adapters.NewTypedNode(node, record.ID.NodeType)
+	typedNode.CreateData = record.CreateData
+	typedNode.MultiPorts = record.MultiPorts
+	typedNode.Updates = record.Updates
+	return s.addWithID(*record.ID, typedNode)
+}
+
+func (s *fileNodeStorage) getPortRef(id *pb.PortIdentifier) (portRef, error) {
+	if id == nil || id.NodeIdentifier == nil {
+		return portRef{}, fmt.Errorf("port identifier not set")
+	}
+
+	node, err := s.Get(id.NodeIdentifier)
+	if err != nil {
+		return portRef{}, err
+	}
+	adapter, err := s.factory.GetAdapter(id.NodeIdentifier.NodeType)
+	if err != nil {
+		return portRef{}, err
+	}
+	port, err := adapter.GetPort(id.PortTag, node.Node)
+	if err != nil {
+		return portRef{}, err
+	}
+	return portRef{node: node, tag: id.PortTag, port: port}, nil
+}
+
+// getPortTags returns tags of all the ports of the node with description,
+// multiports are expanded according to their cardinality
+func getPortTags(description *pb.NodeDescription, multiPorts map[string]int32) []string {
+	var result []string
+	for _, base := range description.BasePorts {
+		prefix := base.Description.Prefix
+		if !base.Description.IsMulti {
+			result = append(result, prefix)
+			continue
+		}
+		result = append(result, common.GetMultiPortTags(prefix, int(multiPorts[prefix]))...)
+	}
+	return result
+}
@@ -0,0 +1,236 @@
+package nodeservice
+
+import (
+	"encoding/json"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/nodeservice/adapters"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type FileNodeStorageTestSuite struct {
+	suite.Suite
+	dir  string
+	path string
+}
+
+func (s *FileNodeStorageTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "node_storage")
+	s.Require().Nil(err)
+	s.dir = dir
+	s.path = filepath.Join(dir, "snapshot.json")
+}
+
+func (s *FileNodeStorageTestSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *FileNodeStorageTestSuite) TestEmpty() {
+	storage, err := NewFileNodeStorage(s.path, nil)
+	s.Require().Nil(err)
+	s.Equal(0, len(storage.GetSessionIDs("")))
+}
+
+func (s *FileNodeStorageTestSuite) TestRestore() {
+	server := s.getServer()
+
+	createReq, _ := GetCreateRequest(
+		[]string{"inlet", "loss", "outlet"},
+		[]string{adapters.InletNodeType, adapters.PressureLossNodeType, adapters.OutletNodeType},
+		[]map[string]float64{{"tStag": 288, "pStag": 1e5}, {"sigma": 0.9}, {}},
+	)
+	createReq.Session = "a"
+	createResp, err := server.CreateNodes(context.Background(), createReq)
+	s.Require().Nil(err)
+	ids := make([]*pb.NodeIdentifier, len(createResp.Items))
+	for i, item := range createResp.Items {
+		s.Require().EqualValues(ok, item.Base.Status, item.Base.Description)
+		ids[i] = item.Identifiers[0]
+	}
+
+	updateReq, _ := GetUpdateRequest(ids[1:2], []map[string]float64{{"sigma": 0.8}})
+	updateResp, err := server.UpdateNodes(context.Background(), updateReq)
+	s.Require().Nil(err)
+	s.Require().EqualValues(ok, updateResp.Items[0].Base.Status)
+
+	linkResp, err := server.Link(context.Background(), &pb.LinkRequest{
+		Items: []*pb.LinkRequest_UnitRequest{
+			s.getLinkItem(ids[0], "pressure_output", ids[1], "pressure_input"),
+			s.getLinkItem(ids[1], "pressure_output", ids[2], "pressure_input"),
+		},
+	})
+	s.Require().Nil(err)
+	s.Require().Equal(0, len(linkResp.Base.Messages))
+
+	restored, err := NewFileNodeStorage(s.path, nil)
+	s.Require().Nil(err)
+	s.Equal(ids, restored.GetSessionIDs("a"))
+
+	loss, err := restored.Get(ids[1])
+	s.Require().Nil(err)
+	s.Equal("loss", loss.Node.GetInstanceName())
+	lossNode := loss.Node.(constructive.PressureLossNode)
+	s.InDelta(0.8, lossNode.Sigma(), 1e-9)
+
+	inlet, err := restored.Get(ids[0])
+	s.Require().Nil(err)
+	inletPort, _ := adapters.NewInletAdapter().GetPort("pressure_output", inlet.Node)
+	s.True(inletPort.GetLinkPort() == lossNode.PressureInput())
+
+	outlet, err := restored.Get(ids[2])
+	s.Require().Nil(err)
+	outletPort, _ := adapters.NewOutletAdapter().GetPort("pressure_input", outlet.Node)
+	s.True(outletPort.GetLinkPort() == lossNode.PressureOutput())
+
+	// names of restored nodes are still reserved
+	_, err = restored.Add("a", adapters.NewTypedNode(lossNode, adapters.PressureLossNodeType))
+	s.Equal(conflict, common.GetCode(err, ok))
+
+	// new nodes do not reuse restored identifiers
+	newNode, err := adapters.NewPressureLossAdapter().Create(&pb.RequestData{DKwargs: map[string]float64{"sigma": 0.9}}, nil)
+	s.Require().Nil(err)
+	id, err := restored.Add("a", adapters.NewTypedNode(newNode, adapters.PressureLossNodeType))
+	s.Require().Nil(err)
+	s.EqualValues(4, id.Id)
+}
+
+func (s *FileNodeStorageTestSuite) TestRestore_WeakLinks() {
+	server := s.getServer()
+
+	createReq, _ := GetCreateRequest(
+		[]string{"loss1", "loss2", "loss3", "loss4"},
+		[]string{
+			adapters.PressureLossNodeType, adapters.PressureLossNodeType,
+			adapters.PressureLossNodeType, adapters.PressureLossNodeType,
+		},
+		[]map[string]float64{{"sigma": 0.9}, {"sigma": 0.9}, {"sigma": 0.9}, {"sigma": 0.9}},
+	)
+	createResp, err := server.CreateNodes(context.Background(), createReq)
+	s.Require().Nil(err)
+	ids := make([]*pb.NodeIdentifier, len(createResp.Items))
+	for i, item := range createResp.Items {
+		ids[i] = item.Identifiers[0]
+	}
+
+	linkTypes := []pb.LinkType{pb.LinkType_WEAK_FIRST, pb.LinkType_WEAK_SECOND, pb.LinkType_WEAK_BOTH}
+	linkReq := &pb.LinkRequest{}
+	for i, linkType := range linkTypes {
+		item := s.getLinkItem(ids[i], "pressure_output", ids[i+1], "pressure_input")
+		item.LinkType = linkType
+		linkReq.Items = append(linkReq.Items, item)
+	}
+	linkResp, err := server.Link(context.Background(), linkReq)
+	s.Require().Nil(err)
+	s.Require().EqualValues(ok, linkResp.Base.Status)
+
+	data, err := ioutil.ReadFile(s.path)
+	s.Require().Nil(err)
+	saved := &storageSnapshot{}
+	s.Require().Nil(json.Unmarshal(data, saved))
+	var savedTypes []pb.LinkType
+	for _, link := range saved.Links {
+		savedTypes = append(savedTypes, link.LinkType)
+	}
+	s.ElementsMatch(linkTypes, savedTypes)
+
+	// restored links are the same as the saved ones
+	restored, err := NewFileNodeStorage(s.path, nil)
+	s.Require().Nil(err)
+	snapshot, err := restored.(*fileNodeStorage).getSnapshot()
+	s.Require().Nil(err)
+	s.Equal(saved.Links, snapshot.Links)
+}
+
+func (s *FileNodeStorageTestSuite) TestRestore_UpdateOrder() {
+	compressorMap, err := adapters.NewTableCompressorMap([]float64{2, 10}, []float64{0.9, 0.8})
+	s.Require().Nil(err)
+	factory := adapters.NewConfiguredNodeAdapterRegistry(adapters.RegistryConfig{
+		CompressorMaps: map[string]adapters.CompressorMap{"m": compressorMap},
+	})
+	storage, err := NewFileNodeStorage(s.path, factory)
+	s.Require().Nil(err)
+	server := NewConfiguredGTEServer(context.Background(), GTEServerConfig{Factory: factory, Storage: storage})
+
+	createReq, _ := GetCreateRequest(
+		[]string{"compressor"},
+		[]string{adapters.CompressorNodeType},
+		[]map[string]float64{{"pi": 6, "eta": 0.86}},
+	)
+	createResp, err := server.CreateNodes(context.Background(), createReq)
+	s.Require().Nil(err)
+	id := createResp.Items[0].Identifiers[0]
+
+	// map and eta can not be passed together, but they are valid in separate updates
+	for _, data := range []*pb.RequestData{
+		{SKwargs: map[string]string{"map": "m"}},
+		{DKwargs: map[string]float64{"eta": 0.8}},
+		{DKwargs: map[string]float64{"pi": 8}},
+	} {
+		updateResp, err := server.UpdateNodes(context.Background(), &pb.NodeUpdateRequest{
+			Items: []*pb.NodeUpdateRequest_UnitRequest{{Identifier: id, Data: data}},
+		})
+		s.Require().Nil(err)
+		s.Require().EqualValues(ok, updateResp.Items[0].Base.Status, updateResp.Items[0].Base.Description)
+	}
+
+	restored, err := NewFileNodeStorage(s.path, factory)
+	s.Require().Nil(err)
+	node, err := restored.Get(id)
+	s.Require().Nil(err)
+	compressor := node.Node.(constructive.CompressorNode)
+	s.InDelta(8, compressor.PiStag(), 1e-9)
+	s.InDelta(0.8, compressor.Eta(), 1e-9)
+	s.Equal(3, len(node.Updates))
+}
+
+func (s *FileNodeStorageTestSuite) TestRestore_AfterDelete() {
+	server := s.getServer()
+
+	createReq, _ := GetCreateRequest(
+		[]string{"loss1", "loss2"},
+		[]string{adapters.PressureLossNodeType, adapters.PressureLossNodeType},
+		[]map[string]float64{{"sigma": 0.9}, {"sigma": 0.9}},
+	)
+	createResp, err := server.CreateNodes(context.Background(), createReq)
+	s.Require().Nil(err)
+
+	_, err = server.DeleteNodes(context.Background(), &pb.NodeIdentifiers{
+		Ids: createResp.Items[0].Identifiers,
+	})
+	s.Require().Nil(err)
+
+	restored, err := NewFileNodeStorage(s.path, nil)
+	s.Require().Nil(err)
+	s.Equal(createResp.Items[1].Identifiers, restored.GetSessionIDs(""))
+}
+
+func (s *FileNodeStorageTestSuite) TestRestore_CorruptedFile() {
+	s.Require().Nil(ioutil.WriteFile(s.path, []byte("{"), 0644))
+	_, err := NewFileNodeStorage(s.path, nil)
+	s.Error(err)
+}
+
+func (s *FileNodeStorageTestSuite) getServer() pb.NodeServiceServer {
+	storage, err := NewFileNodeStorage(s.path, nil)
+	s.Require().Nil(err)
+	return NewConfiguredGTEServer(context.Background(), GTEServerConfig{Storage: storage})
+}
+
+func (s *FileNodeStorageTestSuite) getLinkItem(
+	id1 *pb.NodeIdentifier, tag1 string, id2 *pb.NodeIdentifier, tag2 string,
+) *pb.LinkRequest_UnitRequest {
+	return &pb.LinkRequest_UnitRequest{
+		Id1: &pb.PortIdentifier{NodeIdentifier: id1, PortTag: tag1},
+		Id2: &pb.PortIdentifier{NodeIdentifier: id2, PortTag: tag2},
+	}
+}
+
+func TestFileNodeStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(FileNodeStorageTestSuite))
+}
@@ -0,0 +1,111 @@
+package nodeservice
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"golang.org/x/net/context"
+	"math"
+)
+
+// defaults of iterative graph processing
+const (
+	defaultGraphPrecision     = 1e-5
+	defaultGraphMaxIterations = 100
+)
+
+// processGraph processes nodes in callOrder once if iterate is not set. Otherwise nodes are processed
+// until maximal change of port states between iterations gets below precision or maxIterations is reached.
+// It returns number of performed iterations and convergence flag (which is set for iterative processing only).
+// Processing stops with error of the context as soon as c is done
+func processGraph(
+	c context.Context, callOrder []graph.Node, iterate bool, precision float64, maxIterations int,
+) (int, bool, error) {
+	if !iterate {
+		return 1, false, processCallOrder(c, callOrder)
+	}
+
+	prevStates := getPortStates(callOrder)
+	for i := 1; i <= maxIterations; i++ {
+		if err := processCallOrder(c, callOrder); err != nil {
+			return i, false, err
+		}
+
+		states := getPortStates(callOrder)
+		residual, err := getMaxResidual(prevStates, states)
+		if err != nil {
+			return i, false, err
+		}
+		if residual <= precision {
+			return i, true, nil
+		}
+		prevStates = states
+	}
+	return maxIterations, false, nil
+}
+
+func processCallOrder(c context.Context, callOrder []graph.Node) error {
+	for _, node := range callOrder {
+		if err := getContextErr(c); err != nil {
+			return err
+		}
+		if err := node.Process(); err != nil {
+			return fmt.Errorf("failed to process node %s: %v", getNodeLabel(node), err)
+		}
+	}
+	return nil
+}
+
+// getPortStates returns states of all the ports of the nodes in stable order
+func getPortStates(nodes []graph.Node) []graph.PortState {
+	var result []graph.PortState
+	for _, node := range nodes {
+		for _, port := range node.GetPorts() {
+			result = append(result, port.GetState())
+		}
+	}
+	return result
+}
+
+// getMaxResidual returns maximal residual of corresponding states.
+// Residual of the state which has just been set is infinite
+func getMaxResidual(prevStates, states []graph.PortState) (float64, error) {
+	result := 0.
+	for i, state := range states {
+		prevState := prevStates[i]
+		switch {
+		case state == nil && prevState == nil:
+			continue
+		case state == nil || prevState == nil:
+			return math.Inf(1), nil
+		}
+
+		residual, err := state.MaxResidual(prevState)
+		if err != nil {
+			return 0, err
+		}
+		result = math.Max(result, residual)
+	}
+	return result, nil
+}
+
+// getNodeLabel returns instance name of the node if it is set and type name otherwise
+func getNodeLabel(node graph.Node) string {
+	if name := node.GetInstanceName(); name != "" {
+		return name
+	}
+	return node.GetName()
+}
+
+func getGraphPrecision(precision float64) float64 {
+	if precision <= 0 {
+		return defaultGraphPrecision
+	}
+	return precision
+}
+
+func getGraphMaxIterations(maxIterations int32) int {
+	if maxIterations <= 0 {
+		return defaultGraphMaxIterations
+	}
+	return int(maxIterations)
+}
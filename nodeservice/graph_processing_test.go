@@ -0,0 +1,27 @@
+package nodeservice
+
+import (
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestGetMaxResidual(t *testing.T) {
+	prev := []graph.PortState{nil, graph.NewNumberPortState(1), graph.NewNumberPortState(2)}
+
+	residual, err := getMaxResidual(prev, []graph.PortState{nil, graph.NewNumberPortState(1.5), graph.NewNumberPortState(1)})
+	assert.Nil(t, err)
+	assert.InDelta(t, 1, residual, 1e-9)
+
+	residual, err = getMaxResidual(prev, []graph.PortState{graph.NewNumberPortState(0), prev[1], prev[2]})
+	assert.Nil(t, err)
+	assert.True(t, math.IsInf(residual, 1))
+}
+
+func TestGetGraphDefaults(t *testing.T) {
+	assert.InDelta(t, defaultGraphPrecision, getGraphPrecision(0), 1e-12)
+	assert.InDelta(t, 1e-3, getGraphPrecision(1e-3), 1e-12)
+	assert.Equal(t, defaultGraphMaxIterations, getGraphMaxIterations(-1))
+	assert.Equal(t, 5, getGraphMaxIterations(5))
+}
@@ -3,129 +3,290 @@ package nodeservice
 import (
 	"fmt"
 	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/common/interceptors"
+	"github.com/Sovianum/turbonetwork/common/metrics"
 	"github.com/Sovianum/turbonetwork/nodeservice/adapters"
 	"github.com/Sovianum/turbonetwork/pb"
 	"golang.org/x/net/context"
+	"log"
+	"os"
 	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 )
 
 // NewGTEServer constructs gteServer which implements NodeService interface
+// if factory is nil, registry with all builtin adapters is used
 func NewGTEServer(factory adapters.NodeAdapterFactory) pb.NodeServiceServer {
-	return &gteServer{
-		nodeStorage: NewMapNodeStorage(),
-		factory:     factory,
+	return NewConfiguredGTEServer(context.Background(), GTEServerConfig{Factory: factory})
+}
+
+// NewLeasedGTEServer constructs gteServer which deletes sessions not renewed during lease duration.
+// Expired sessions are checked every reaper interval until ctx is done
+func NewLeasedGTEServer(ctx context.Context, factory adapters.NodeAdapterFactory, config LeaseConfig) pb.NodeServiceServer {
+	return NewConfiguredGTEServer(ctx, GTEServerConfig{Factory: factory, Lease: &config})
+}
+
+// GTEServerConfig contains optional components of gteServer
+type GTEServerConfig struct {
+	// Factory provides node adapters, registry with all builtin adapters is used if it is nil
+	Factory adapters.NodeAdapterFactory
+	// Storage keeps nodes, in-memory storage is used if it is nil
+	Storage NodeStorage
+	// Lease enables deletion of sessions which are not renewed, sessions live forever if it is nil
+	Lease *LeaseConfig
+	// Metrics collects statistics of the server, metrics are not collected if it is nil
+	Metrics *metrics.Registry
+	// Logger receives reports of the background jobs, standard logger is used if it is nil
+	Logger *log.Logger
+}
+
+// NewConfiguredGTEServer constructs gteServer out of config. Background jobs of the server
+// (like reaping of expired sessions) run until ctx is done
+func NewConfiguredGTEServer(ctx context.Context, config GTEServerConfig) pb.NodeServiceServer {
+	if config.Factory == nil {
+		config.Factory = adapters.NewDefaultNodeAdapterRegistry()
+	}
+	if config.Storage == nil {
+		config.Storage = NewMapNodeStorage()
+	}
+	if config.Logger == nil {
+		config.Logger = log.New(os.Stderr, "", log.LstdFlags)
 	}
+
+	server := &gteServer{
+		nodeStorage: config.Storage,
+		factory:     config.Factory,
+		logger:      config.Logger,
+		adapterErrors: config.Metrics.NewCounterVec(
+			"turbonetwork_node_adapter_errors_total", "Number of errors returned by node adapters", "node_type",
+		),
+		solverIterations: config.Metrics.NewSolverIterations(),
+	}
+	config.Metrics.NewGaugeVecFunc(
+		"turbonetwork_nodes", "Number of stored nodes", "node_type", server.countNodes,
+	)
+	if config.Lease != nil {
+		lease := config.Lease.withDefaults()
+		server.leases = newLeaseTracker(lease.Duration)
+		// sessions restored by the storage get a full lease, otherwise they would never expire
+		config.Storage.Range(func(id *pb.NodeIdentifier, node *adapters.TypedNode) bool {
+			server.leases.renew(id.Session)
+			return true
+		})
+		go server.runReaper(ctx, lease.ReaperInterval)
+	}
+	return server
 }
 
 type gteServer struct {
 	nodeStorage NodeStorage
 	factory     adapters.NodeAdapterFactory
+	// leases is nil if sessions live until explicit deletion
+	leases *leaseTracker
+	// portLock guards links and states of the ports and snapshots of the storage. Handlers
+	// which change them hold it exclusively, so that the reaper never unlinks nodes
+	// in the middle of another request
+	portLock sync.RWMutex
+	logger   *log.Logger
+
+	adapterErrors    *metrics.CounterVec
+	solverIterations *metrics.HistogramVec
 }
 
 func (s *gteServer) CreateNodes(c context.Context, r *pb.NodeCreateRequest) (resp *pb.NodeModifyResponse, e error) {
-	defer func() {
-		if r := recover(); r != nil {
-			resp = getModifyErrResponse(fmt.Sprintf("%v, %s", r, debug.Stack()), internalError)
-		}
-	}()
+	s.portLock.Lock()
+	defer s.portLock.Unlock()
 
 	responseItems := make([]*pb.NodeModifyResponse_UnitResponse, len(r.Items))
+	log := &rollbackLog{}
 
+	var ctxErr error
 	for i, item := range r.Items {
+		if ctxErr = getContextErr(c); ctxErr != nil {
+			break
+		}
+
 		adapter, err := s.factory.GetAdapter(item.NodeType)
 		if err != nil {
 			responseItems[i] = getModifyErrResponseItem(err.Error(), notFound)
 			continue
 		}
 
-		node, nodeErr := adapter.Create(item.Data)
+		node, nodeErr := adapter.Create(item.Data, item.MultiPorts)
 		if nodeErr != nil {
-			responseItems[i] = getModifyErrResponseItem(nodeErr.Error(), internalError)
+			// type is known to the factory here, so it does not add arbitrary labels
+			s.adapterErrors.Inc(item.NodeType)
+			responseItems[i] = getModifyErrResponseItem(nodeErr.Error(), common.GetCode(nodeErr, internalError))
 			continue
 		}
 		node.SetName(item.NodeName)
 
-		id, idErr := s.nodeStorage.Add(adapters.NewTypedNode(node, item.NodeType))
+		typedNode := adapters.NewTypedNode(node, item.NodeType)
+		typedNode.CreateData = item.Data
+		typedNode.MultiPorts = item.MultiPorts
+
+		id, idErr := s.nodeStorage.Add(r.Session, typedNode)
 		if idErr != nil {
-			responseItems[i] = getModifyErrResponseItem(idErr.Error(), internalError)
+			responseItems[i] = getModifyErrResponseItem(idErr.Error(), common.GetCode(idErr, internalError))
 			continue
 		}
 
+		log.add(func() error {
+			return s.nodeStorage.Drop(id)
+		})
 		responseItems[i] = getModifySuccessResponseItem(id)
 	}
 
-	return getModifySuccessResponse(responseItems), nil
+	// lease is renewed after nodes are added so that reaper can not miss them
+	if s.leases != nil {
+		s.leases.renew(r.Session)
+	}
+
+	if ctxErr != nil {
+		resp, e = cancelModify(r.Atomic, log, ctxErr)
+	} else {
+		resp = finishModify(r.Atomic, responseItems, log)
+	}
+	// failed and reverted requests must not leave lease of the session without nodes
+	s.dropEmptyLease(r.Session)
+	return s.persist(c, resp), e
 }
 
 func (s *gteServer) UpdateNodes(c context.Context, r *pb.NodeUpdateRequest) (resp *pb.NodeModifyResponse, e error) {
-	defer func() {
-		if r := recover(); r != nil {
-			resp = getModifyErrResponse(fmt.Sprintf("%v, %s", r, debug.Stack()), internalError)
-		}
-	}()
+	s.portLock.Lock()
+	defer s.portLock.Unlock()
 
 	responseItems := make([]*pb.NodeModifyResponse_UnitResponse, len(r.Items))
+	log := &rollbackLog{}
 
+	var ctxErr error
 	for i, item := range r.Items {
-		node, nodeErr := s.nodeStorage.Get(item.Identifier)
+		if ctxErr = getContextErr(c); ctxErr != nil {
+			break
+		}
+
+		id, idErr := s.resolveID(item.Identifier)
+		if idErr != nil {
+			responseItems[i] = getModifyErrResponseItem(idErr.Error(), notFound)
+			continue
+		}
+
+		node, nodeErr := s.nodeStorage.Get(id)
 		if nodeErr != nil {
 			responseItems[i] = getModifyErrResponseItem(nodeErr.Error(), notFound)
 			continue
 		}
 
-		adapter, err := s.factory.GetAdapter(item.Identifier.NodeType)
+		adapter, err := s.factory.GetAdapter(id.NodeType)
 		if err != nil {
 			responseItems[i] = getModifyErrResponseItem(err.Error(), notFound)
 			continue
 		}
 
+		// state is saved only for atomic requests because it is necessary for rollback only
+		var prevState *pb.NodeState
+		if r.Atomic {
+			var stateErr error
+			if prevState, stateErr = adapter.GetState(node.Node, nil); stateErr != nil {
+				s.adapterErrors.Inc(node.NodeType)
+				responseItems[i] = getModifyErrResponseItem(stateErr.Error(), internalError)
+				continue
+			}
+		}
+
 		updateErr := adapter.Update(node.Node, item.Data)
 		if updateErr != nil {
-			responseItems[i] = getModifyErrResponseItem(updateErr.Error(), internalError)
+			s.adapterErrors.Inc(node.NodeType)
+			responseItems[i] = getModifyErrResponseItem(updateErr.Error(), common.GetCode(updateErr, internalError))
 			continue
 		}
-		responseItems[i] = getModifySuccessResponseItem(item.Identifier)
+
+		prevUpdates := node.Updates
+		applied := node.GetAppliedData()
+		node.AddUpdate(item.Data)
+
+		if prevState != nil {
+			rollbackData := getRollbackData(item.Data, applied, prevState.State)
+			log.add(func() error {
+				node.Updates = prevUpdates
+				return adapter.Update(node.Node, rollbackData)
+			})
+		}
+		responseItems[i] = getModifySuccessResponseItem(id)
 	}
 
-	return getModifySuccessResponse(responseItems), nil
+	if ctxErr != nil {
+		resp, e = cancelModify(r.Atomic, log, ctxErr)
+		return s.persist(c, resp), e
+	}
+	return s.persist(c, finishModify(r.Atomic, responseItems, log)), nil
 }
 
 func (s *gteServer) DeleteNodes(c context.Context, ids *pb.NodeIdentifiers) (resp *pb.NodeModifyResponse, e error) {
-	defer func() {
-		if r := recover(); r != nil {
-			resp = getModifyErrResponse(fmt.Sprintf("%v, %s", r, debug.Stack()), internalError)
-		}
-	}()
+	s.portLock.Lock()
+	defer s.portLock.Unlock()
 
 	responseItems := make([]*pb.NodeModifyResponse_UnitResponse, len(ids.Ids))
+	sessions := make(map[string]bool)
+
+	for i, item := range ids.Ids {
+		id, idErr := s.resolveID(item)
+		if idErr != nil {
+			responseItems[i] = getModifyErrResponseItem(idErr.Error(), notFound)
+			continue
+		}
+
+		node, nodeErr := s.nodeStorage.Get(id)
+		if nodeErr != nil {
+			responseItems[i] = getModifyErrResponseItem(nodeErr.Error(), notFound)
+			continue
+		}
+
+		// node ports are detached so that linked nodes do not keep references to the deleted one
+		unlinkNode(node)
 
-	for i, id := range ids.Ids {
 		if err := s.nodeStorage.Drop(id); err != nil {
 			responseItems[i] = getModifyErrResponseItem(err.Error(), notFound)
 		} else {
 			responseItems[i] = getModifySuccessResponseItem(id)
 		}
+		sessions[id.Session] = true
 	}
 
-	return getModifySuccessResponse(responseItems), nil
+	for session := range sessions {
+		s.dropEmptyLease(session)
+	}
+	return s.persist(c, getModifySuccessResponse(responseItems)), nil
 }
 
 func (s *gteServer) GetNodesState(c context.Context, r *pb.NodeStateRequest) (resp *pb.NodeStateResponse, e error) {
-	defer func() {
-		if r := recover(); r != nil {
-			resp = getStateErrResponse(fmt.Sprintf("%v, %s", r, debug.Stack()), internalError)
-		}
-	}()
+	s.portLock.RLock()
+	defer s.portLock.RUnlock()
 
 	responseItems := make([]*pb.NodeStateResponse_UnitResponse, len(r.Items))
 	for i, item := range r.Items {
-		adapter, err := s.factory.GetAdapter(item.Identifier.NodeType)
+		if ctxErr := getContextErr(c); ctxErr != nil {
+			reqErr := getContextError(ctxErr)
+			return getStateErrResponse(reqErr), reqErr
+		}
+
+		id, idErr := s.resolveID(item.Identifier)
+		if idErr != nil {
+			responseItems[i] = getStateErrResponseItem(idErr.Error(), notFound)
+			continue
+		}
+
+		adapter, err := s.factory.GetAdapter(id.NodeType)
 		if err != nil {
 			responseItems[i] = getStateErrResponseItem(err.Error(), notFound)
 			continue
 		}
 
-		node, nodeErr := s.nodeStorage.Get(item.Identifier)
+		node, nodeErr := s.nodeStorage.Get(id)
 		if nodeErr != nil {
 			responseItems[i] = getStateErrResponseItem(nodeErr.Error(), notFound)
 			continue
@@ -133,114 +294,491 @@ func (s *gteServer) GetNodesState(c context.Context, r *pb.NodeStateRequest) (re
 
 		state, stateErr := adapter.GetState(node.Node, item.RequiredFields)
 		if stateErr != nil {
+			s.adapterErrors.Inc(node.NodeType)
 			responseItems[i] = getStateErrResponseItem(stateErr.Error(), internalError)
 			continue
 		}
 
-		responseItems[i] = getStateSuccessResponseItem(item.Identifier, state)
+		responseItems[i] = getStateSuccessResponseItem(id, state)
 	}
 
 	return getStateSuccessResponse(responseItems), nil
 }
 
-func (s *gteServer) GetPortsState(context.Context, *pb.PortStateRequest) (*pb.PortStateResponse, error) {
-	panic("implement me")
-}
+func (s *gteServer) ListNodes(c context.Context, r *pb.NodeListRequest) (resp *pb.NodeListResponse, e error) {
+	afterID, err := parsePageToken(r.PageToken)
+	if err != nil {
+		reqErr := common.NewError(badRequest, "%v", err)
+		return getListErrResponse(reqErr), reqErr
+	}
 
-func (s *gteServer) SetPortsState(context.Context, *pb.PortUpdateRequest) (*pb.PortModifyResponse, error) {
-	panic("implement me")
+	var nodes []*pb.NodeListResponse_UnitResponse
+	s.nodeStorage.Range(func(id *pb.NodeIdentifier, node *adapters.TypedNode) bool {
+		name := node.Node.GetInstanceName()
+		if id.Session == r.Session && id.Id > afterID && matchListFilters(r, id, name) {
+			nodes = append(nodes, getListResponseItem(id, name))
+		}
+		return true
+	})
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Identifier.Id < nodes[j].Identifier.Id
+	})
+
+	pageSize := getPageSize(r.PageSize)
+	if len(nodes) <= pageSize {
+		return getListSuccessResponse(nodes, ""), nil
+	}
+	nodes = nodes[:pageSize]
+	return getListSuccessResponse(nodes, getPageToken(nodes[pageSize-1].Identifier.Id)), nil
 }
 
-func (s *gteServer) Process(c context.Context, r *pb.NodeIdentifiers) (resp *pb.NodeModifyResponse, error error) {
-	defer func() {
-		if r := recover(); r != nil {
-			resp = getModifyErrResponse(fmt.Sprintf("%v, %s", r, debug.Stack()), internalError)
-			return
+func (s *gteServer) GetPortsState(c context.Context, r *pb.PortStateRequest) (resp *pb.PortStateResponse, e error) {
+	s.portLock.RLock()
+	defer s.portLock.RUnlock()
+
+	responseItems := make([]*pb.PortStateResponse_UnitResponse, len(r.Items))
+	for i, item := range r.Items {
+		if ctxErr := getContextErr(c); ctxErr != nil {
+			reqErr := getContextError(ctxErr)
+			return getPortStateErrResponse(reqErr), reqErr
 		}
-	}()
 
-	responseItems := make([]*pb.NodeModifyResponse_UnitResponse, len(r.Ids))
+		_, port, portErr := s.getPort(item.Identifier)
+		if portErr != nil {
+			responseItems[i] = getPortStateErrResponseItem(portErr.Error(), notFound)
+			continue
+		}
 
-	for i, item := range r.Ids {
-		node, nodeErr := s.nodeStorage.Get(item)
-		if nodeErr != nil {
-			responseItems[i] = getModifyErrResponseItem(nodeErr.Error(), notFound)
+		state, stateErr := adapters.PortStateToPB(item.Identifier.PortTag, port.GetState(), item.RequiredFields)
+		if stateErr != nil {
+			responseItems[i] = getPortStateErrResponseItem(stateErr.Error(), internalError)
 			continue
 		}
 
-		err := node.Node.Process()
-		if err != nil {
-			responseItems[i] = getModifyErrResponseItem(err.Error(), internalError)
+		responseItems[i] = getPortStateSuccessResponseItem(item.Identifier, state)
+	}
+
+	return getPortStateSuccessResponse(responseItems), nil
+}
+
+func (s *gteServer) SetPortsState(c context.Context, r *pb.PortUpdateRequest) (resp *pb.PortModifyResponse, e error) {
+	s.portLock.Lock()
+	defer s.portLock.Unlock()
+
+	responseItems := make([]*pb.PortModifyResponse_UnitResponse, len(r.Items))
+	for i, item := range r.Items {
+		if ctxErr := getContextErr(c); ctxErr != nil {
+			reqErr := getContextError(ctxErr)
+			return getPortModifyErrResponse(reqErr), reqErr
+		}
+
+		_, port, portErr := s.getPort(item.Identifier)
+		if portErr != nil {
+			responseItems[i] = getPortModifyErrResponseItem(portErr.Error(), notFound)
 			continue
 		}
 
-		responseItems[i] = getModifySuccessResponseItem(item)
+		state, stateErr := adapters.PortStateFromPB(item.State)
+		if stateErr != nil {
+			responseItems[i] = getPortModifyErrResponseItem(stateErr.Error(), badRequest)
+			continue
+		}
+
+		port.SetState(state)
+		responseItems[i] = getPortModifySuccessResponseItem(item.Identifier)
+	}
+
+	return getPortModifySuccessResponse(responseItems), nil
+}
+
+func (s *gteServer) Process(c context.Context, r *pb.NodeIdentifiers) (resp *pb.NodeModifyResponse, e error) {
+	s.portLock.Lock()
+	defer s.portLock.Unlock()
+
+	responseItems := make([]*pb.NodeModifyResponse_UnitResponse, len(r.Ids))
+	for i, item := range r.Ids {
+		if ctxErr := getContextErr(c); ctxErr != nil {
+			reqErr := getContextError(ctxErr)
+			return getModifyErrResponse(reqErr), reqErr
+		}
+
+		responseItems[i] = s.processNode(item)
 	}
 
 	return getModifySuccessResponse(responseItems), nil
 }
 
-func (s *gteServer) Link(c context.Context, r *pb.LinkRequest) (resp *pb.NodeModifyResponse, e error) {
-	defer func() {
-		if r := recover(); r != nil {
-			resp = getModifyErrResponse(fmt.Sprintf("%v, %s", r, debug.Stack()), internalError)
-			return
+// ProcessStream works as Process but sends result of every node as soon as it is processed.
+// Processing stops when stream context is done
+func (s *gteServer) ProcessStream(r *pb.NodeIdentifiers, stream pb.NodeService_ProcessStreamServer) (e error) {
+	ctx := stream.Context()
+	for _, item := range r.Ids {
+		if err := ctx.Err(); err != nil {
+			return getContextError(err)
 		}
-	}()
 
-	portExtractor := func(portIdentifier *pb.PortIdentifier) (graph.Port, error) {
-		node, nodeErr := s.nodeStorage.Get(portIdentifier.NodeIdentifier)
+		// lock is not held while the item is sent, so that slow clients do not block other requests
+		if err := stream.Send(s.processStreamItem(item)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *gteServer) ProcessGraph(c context.Context, r *pb.ProcessGraphRequest) (resp *pb.ProcessGraphResponse, e error) {
+	s.portLock.Lock()
+	defer s.portLock.Unlock()
+
+	nodes := make([]graph.Node, len(r.Ids))
+	nodeIDs := make(map[graph.Node]*pb.NodeIdentifier, len(r.Ids))
+	for i, item := range r.Ids {
+		id, idErr := s.resolveID(item)
+		if idErr != nil {
+			reqErr := common.NewError(common.GetCode(idErr, notFound), "%v", idErr)
+			return getProcessGraphErrResponse(reqErr), reqErr
+		}
+
+		node, nodeErr := s.nodeStorage.Get(id)
 		if nodeErr != nil {
-			return nil, nodeErr
+			reqErr := common.NewError(notFound, "%v", nodeErr)
+			return getProcessGraphErrResponse(reqErr), reqErr
+		}
+		if _, ok := nodeIDs[node.Node]; ok {
+			reqErr := common.NewError(badRequest, "node %d is listed twice", id.Id)
+			return getProcessGraphErrResponse(reqErr), reqErr
 		}
 
-		adapter, err := s.factory.GetAdapter(portIdentifier.NodeIdentifier.NodeType)
-		//getter, err := s.portGetterFactory.GetPortGetter(portIdentifier.NodeIdentifier.NodeType)
-		if err != nil {
-			return nil, err
+		nodes[i] = node.Node
+		nodeIDs[node.Node] = id
+	}
+
+	callOrder, orderErr := graph.GetCallOrder(nodes)
+	if orderErr != nil {
+		reqErr := common.NewError(badRequest, "%v", orderErr)
+		return getProcessGraphErrResponse(reqErr), reqErr
+	}
+	callOrderIDs := make([]*pb.NodeIdentifier, len(callOrder))
+	for i, node := range callOrder {
+		callOrderIDs[i] = nodeIDs[node]
+	}
+
+	iterations, converged, processErr := processGraph(
+		c, callOrder, r.Iterate, getGraphPrecision(r.Precision), getGraphMaxIterations(r.MaxIterations),
+	)
+	if r.Iterate {
+		s.solverIterations.Observe("node", float64(iterations))
+	}
+	resp = getProcessGraphSuccessResponse(callOrderIDs, iterations, converged)
+	if ctxErr := getContextErr(c); ctxErr != nil && processErr == ctxErr {
+		reqErr := getContextError(ctxErr)
+		resp.Base = getBaseErrResponseItem(reqErr.Msg, reqErr.Code)
+		return resp, reqErr
+	}
+	if processErr != nil {
+		resp.Base = getBaseErrResponseItem(processErr.Error(), internalError)
+		return resp, common.NewError(internalError, "%v", processErr)
+	}
+	if r.Iterate && !converged {
+		resp.Base.Messages = append(resp.Base.Messages, fmt.Sprintf("not converged in %d iterations", iterations))
+	}
+	return resp, nil
+}
+
+func (s *gteServer) Link(c context.Context, r *pb.LinkRequest) (resp *pb.NodeModifyResponse, e error) {
+	s.portLock.Lock()
+	defer s.portLock.Unlock()
+
+	responseItems := make([]*pb.NodeModifyResponse_UnitResponse, len(r.Items))
+	log := &rollbackLog{}
+
+	var ctxErr error
+	for i, item := range r.Items {
+		if ctxErr = getContextErr(c); ctxErr != nil {
+			break
 		}
 
-		port, portErr := adapter.GetPort(portIdentifier.PortTag, node.Node)
-		if portErr != nil {
-			return nil, portErr
+		if err := checkSameSession(item.Id1, item.Id2); err != nil {
+			responseItems[i] = getModifyErrResponseItem(err.Error(), badRequest)
+			continue
 		}
 
-		return port, nil
+		nodeID1, ref1, portErr1 := s.getPortRef(item.Id1)
+		if portErr1 != nil {
+			responseItems[i] = getModifyErrResponseItem(portErr1.Error(), notFound)
+			continue
+		}
+
+		nodeID2, ref2, portErr2 := s.getPortRef(item.Id2)
+		if portErr2 != nil {
+			responseItems[i] = getModifyErrResponseItem(portErr2.Error(), notFound)
+			continue
+		}
+
+		// ports may have been linked before, so previous links are restored on rollback
+		port1, port2 := ref1.port, ref2.port
+		prevLink1, prevLink2 := port1.GetLinkPort(), port2.GetLinkPort()
+		log.add(func() error {
+			port1.SetLinkPort(prevLink1)
+			port2.SetLinkPort(prevLink2)
+			return nil
+		})
+
+		linkPorts(ref1, ref2, item.LinkType)
+		responseItems[i] = getModifySuccessResponseItem(nodeID1, nodeID2)
+	}
+
+	if ctxErr != nil {
+		resp, e = cancelModify(r.Atomic, log, ctxErr)
+		return s.persist(c, resp), e
 	}
+	return s.persist(c, finishModify(r.Atomic, responseItems, log)), nil
+}
+
+func (s *gteServer) Unlink(c context.Context, r *pb.LinkRequest) (resp *pb.NodeModifyResponse, e error) {
+	s.portLock.Lock()
+	defer s.portLock.Unlock()
 
 	responseItems := make([]*pb.NodeModifyResponse_UnitResponse, len(r.Items))
 	for i, item := range r.Items {
-		port1, portErr1 := portExtractor(item.Id1)
+		nodeID1, ref1, portErr1 := s.getPortRef(item.Id1)
 		if portErr1 != nil {
 			responseItems[i] = getModifyErrResponseItem(portErr1.Error(), notFound)
 			continue
 		}
 
-		port2, portErr2 := portExtractor(item.Id2)
+		nodeID2, ref2, portErr2 := s.getPortRef(item.Id2)
 		if portErr2 != nil {
 			responseItems[i] = getModifyErrResponseItem(portErr2.Error(), notFound)
 			continue
 		}
 
-		switch item.LinkType {
-		case pb.LinkType_WEAK_FIRST:
-			graph.Link(graph.NewWeakPort(port1), port2)
-		case pb.LinkType_WEAK_SECOND:
-			graph.Link(port1, graph.NewWeakPort(port2))
-		case pb.LinkType_WEAK_BOTH:
-			graph.Link(graph.NewWeakPort(port1), graph.NewWeakPort(port2))
-		default:
-			graph.Link(port1, port2)
+		if err := unlinkPorts(ref1, ref2); err != nil {
+			responseItems[i] = getModifyErrResponseItem(err.Error(), badRequest)
+			continue
 		}
-		responseItems[i] = getModifySuccessResponseItem(item.Id1.NodeIdentifier, item.Id2.NodeIdentifier)
+		responseItems[i] = getModifySuccessResponseItem(nodeID1, nodeID2)
 	}
 
-	return getModifySuccessResponse(responseItems), nil
+	return s.persist(c, getModifySuccessResponse(responseItems)), nil
+}
+
+func (s *gteServer) DeleteSession(c context.Context, r *pb.SessionIdentifier) (resp *pb.NodeModifyResponse, e error) {
+	if s.leases != nil {
+		s.leases.drop(r.Session)
+	}
+	return s.DeleteNodes(c, &pb.NodeIdentifiers{Ids: s.nodeStorage.GetSessionIDs(r.Session)})
+}
+
+func (s *gteServer) RenewSession(c context.Context, r *pb.SessionIdentifier) (*pb.LeaseResponse, error) {
+	// lock keeps nodes of the session from being added or deleted between the checks
+	s.portLock.RLock()
+	defer s.portLock.RUnlock()
+
+	if len(s.nodeStorage.GetSessionIDs(r.Session)) == 0 {
+		reqErr := common.NewError(notFound, "session %s not found", r.Session)
+		return getLeaseErrResponse(reqErr), reqErr
+	}
+	if s.leases == nil {
+		return getLeaseResponse(0), nil
+	}
+	if !s.leases.renewActive(r.Session) {
+		reqErr := common.NewError(notFound, "lease of session %s has expired", r.Session)
+		return getLeaseErrResponse(reqErr), reqErr
+	}
+	return getLeaseResponse(s.leases.duration), nil
 }
 
 func (s *gteServer) GetDescription(context.Context, *pb.Empty) (*pb.ServiceDescription, error) {
+	nodes, err := getNodeDescriptions(s.factory)
+	if err != nil {
+		return nil, common.NewError(common.GetCode(err, internalError), "%v", err)
+	}
+
 	return &pb.ServiceDescription{
 		Description: "gte_service",
-		Nodes:       nodeDescriptionList,
+		Nodes:       nodes,
 	}, nil
 }
+
+// persist saves snapshot of the storage if it supports persistence.
+// Failed snapshot does not revert the request but is reported in response messages.
+// Failures which do not change status of the request (failed snapshot or rollback) are logged too
+func (s *gteServer) persist(c context.Context, resp *pb.NodeModifyResponse) *pb.NodeModifyResponse {
+	if resp.Base.Status == internalError {
+		s.logf(c, "request failed: %s", resp.Base.Description)
+	}
+
+	storage, ok := s.nodeStorage.(PersistentNodeStorage)
+	if !ok {
+		return resp
+	}
+	if err := storage.Snapshot(); err != nil {
+		msg := fmt.Sprintf("failed to save snapshot: %s", err.Error())
+		s.logf(c, "%s", msg)
+		resp.Base.Messages = append(resp.Base.Messages, msg)
+	}
+	return resp
+}
+
+// logf writes line of the server log prefixed with id of the request c belongs to
+func (s *gteServer) logf(c context.Context, format string, args ...interface{}) {
+	interceptors.Logf(s.logger, c, format, args...)
+}
+
+// countNodes returns number of stored nodes by node type
+func (s *gteServer) countNodes() map[string]float64 {
+	result := make(map[string]float64)
+	s.nodeStorage.Range(func(id *pb.NodeIdentifier, node *adapters.TypedNode) bool {
+		result[node.NodeType]++
+		return true
+	})
+	return result
+}
+
+// dropEmptyLease drops lease of the session which has no nodes, so that only existing sessions have leases
+func (s *gteServer) dropEmptyLease(session string) {
+	if s.leases != nil && len(s.nodeStorage.GetSessionIDs(session)) == 0 {
+		s.leases.drop(session)
+	}
+}
+
+func (s *gteServer) runReaper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.reapExpired()
+		}
+	}
+}
+
+// reapExpired deletes nodes of all the sessions with expired leases
+func (s *gteServer) reapExpired() {
+	for _, session := range s.leases.popExpired() {
+		s.reapSession(session)
+	}
+}
+
+// reapSession deletes nodes of the expired session. Reaper runs outside of the interceptors,
+// so panics of the adapters are recovered and logged here
+func (s *gteServer) reapSession(session string) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Printf("reaper session=%s panic=\"%v\"\n%s", session, r, debug.Stack())
+		}
+	}()
+
+	resp, _ := s.DeleteNodes(context.Background(), &pb.NodeIdentifiers{Ids: s.nodeStorage.GetSessionIDs(session)})
+	for _, item := range resp.Items {
+		if item.Base.Status != ok {
+			s.logger.Printf("reaper session=%s error=\"%s\"", session, item.Base.Description)
+		}
+	}
+	for _, msg := range resp.Base.Messages {
+		s.logger.Printf("reaper session=%s message=\"%s\"", session, msg)
+	}
+}
+
+func matchListFilters(r *pb.NodeListRequest, id *pb.NodeIdentifier, name string) bool {
+	if r.NodeType != "" && id.NodeType != r.NodeType {
+		return false
+	}
+	return strings.HasPrefix(name, r.NamePrefix)
+}
+
+// checkSameSession checks that ports belong to the same session because nodes of different sessions
+// must not affect each other
+func checkSameSession(id1, id2 *pb.PortIdentifier) error {
+	session1 := id1.GetNodeIdentifier().GetSession()
+	session2 := id2.GetNodeIdentifier().GetSession()
+	if session1 != session2 {
+		return fmt.Errorf("ports of different sessions (\"%s\" and \"%s\") can not be linked", session1, session2)
+	}
+	return nil
+}
+
+// processNode processes node with identifier item and returns result of the item
+func (s *gteServer) processNode(item *pb.NodeIdentifier) *pb.NodeModifyResponse_UnitResponse {
+	id, idErr := s.resolveID(item)
+	if idErr != nil {
+		return getModifyErrResponseItem(idErr.Error(), notFound)
+	}
+
+	node, nodeErr := s.nodeStorage.Get(id)
+	if nodeErr != nil {
+		return getModifyErrResponseItem(nodeErr.Error(), notFound)
+	}
+
+	if err := node.Node.Process(); err != nil {
+		return getModifyErrResponseItem(err.Error(), internalError)
+	}
+	return getModifySuccessResponseItem(id)
+}
+
+// processStreamItem processes node under the port lock. Duration of the item does not include
+// waiting for the lock. Lock is released even if the node panics, so that the server stays usable
+func (s *gteServer) processStreamItem(item *pb.NodeIdentifier) *pb.NodeModifyResponse_UnitResponse {
+	s.portLock.Lock()
+	defer s.portLock.Unlock()
+
+	start := time.Now()
+	responseItem := s.processNode(item)
+	responseItem.DurationMicros = int64(time.Since(start) / time.Microsecond)
+	return responseItem
+}
+
+// resolveID returns identifier of the stored node. If id is not set node is looked up
+// by name in the session of the identifier
+func (s *gteServer) resolveID(id *pb.NodeIdentifier) (*pb.NodeIdentifier, error) {
+	if id == nil {
+		return nil, fmt.Errorf("node identifier not set")
+	}
+	if id.Id != 0 || id.Name == "" {
+		return id, nil
+	}
+	return s.nodeStorage.FindByName(id.Session, id.Name)
+}
+
+// resolvePortNodeID returns identifier of the node port belongs to.
+// Node name of the port identifier is used if node identifier does not contain id
+func (s *gteServer) resolvePortNodeID(portIdentifier *pb.PortIdentifier) (*pb.NodeIdentifier, error) {
+	nodeID := portIdentifier.NodeIdentifier
+	if nodeID.GetId() != 0 || portIdentifier.NodeName == "" {
+		return s.resolveID(nodeID)
+	}
+	return s.nodeStorage.FindByName(nodeID.GetSession(), portIdentifier.NodeName)
+}
+
+func (s *gteServer) getPort(portIdentifier *pb.PortIdentifier) (*pb.NodeIdentifier, graph.Port, error) {
+	nodeID, ref, err := s.getPortRef(portIdentifier)
+	return nodeID, ref.port, err
+}
+
+func (s *gteServer) getPortRef(portIdentifier *pb.PortIdentifier) (*pb.NodeIdentifier, portRef, error) {
+	nodeID, idErr := s.resolvePortNodeID(portIdentifier)
+	if idErr != nil {
+		return nil, portRef{}, idErr
+	}
+
+	node, nodeErr := s.nodeStorage.Get(nodeID)
+	if nodeErr != nil {
+		return nil, portRef{}, nodeErr
+	}
+
+	adapter, err := s.factory.GetAdapter(nodeID.NodeType)
+	if err != nil {
+		return nil, portRef{}, err
+	}
+
+	port, portErr := adapter.GetPort(portIdentifier.PortTag, node.Node)
+	if portErr != nil {
+		s.adapterErrors.Inc(node.NodeType)
+		return nil, portRef{}, portErr
+	}
+
+	return nodeID, portRef{node: node, tag: portIdentifier.PortTag, port: port}, nil
+}
@@ -1,14 +1,28 @@
 package nodeservice
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbocycle/impl/engine/nodes/constructive"
+	"github.com/Sovianum/turbocycle/impl/engine/states"
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/common/interceptors"
+	"github.com/Sovianum/turbonetwork/common/metrics"
 	"github.com/Sovianum/turbonetwork/nodeservice/adapters"
 	"github.com/Sovianum/turbonetwork/nodeservice/mocks"
 	"github.com/Sovianum/turbonetwork/pb"
 	"github.com/stretchr/testify/suite"
-	"strings"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 type GTEServerTestSuite struct {
@@ -32,7 +46,7 @@ func (s *GTEServerTestSuite) SetupTest() {
 func (s *GTEServerTestSuite) TestCreateNodes_Success() {
 	s.factory.ExpectResponse(
 		mocks.NodeAdapterMock{
-			CreateFunc: func(data *pb.RequestData) (graph.Node, error) {
+			CreateFunc: func(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
 				return graph.NewTestNode(0, 0, true, func() error {
 					return nil
 				}), nil
@@ -52,6 +66,32 @@ func (s *GTEServerTestSuite) TestCreateNodes_Success() {
 	s.EqualValues(1, response.Items[0].Identifiers[0].Id)
 }
 
+func (s *GTEServerTestSuite) TestCreateNodes_MultiPorts() {
+	var passedMultiPorts map[string]int32
+	s.factory.ExpectResponse(
+		mocks.NodeAdapterMock{
+			CreateFunc: func(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+				passedMultiPorts = multiPorts
+				return graph.NewTestNode(0, 0, true, func() error {
+					return nil
+				}), nil
+			},
+		}, nil,
+	)
+
+	ids := s.getNodeIdentifiers(1)
+	s.storage.ExpectAddResponse(ids.Ids[0], nil)
+
+	req := s.getValidCreateRequest()
+	req.Items[0].MultiPorts = map[string]int32{"port": 3}
+	response, err := s.server.CreateNodes(nil, req)
+
+	s.Require().Nil(err)
+	s.Require().Equal(1, len(response.Items))
+	s.EqualValues(ok, response.Items[0].Base.Status)
+	s.Equal(map[string]int32{"port": 3}, passedMultiPorts)
+}
+
 func (s *GTEServerTestSuite) TestCreateNodes_ConstructorNotFound() {
 	e := fmt.Errorf("err not found")
 	s.factory.ExpectResponse(nil, e)
@@ -69,7 +109,7 @@ func (s *GTEServerTestSuite) TestCreateNodes_ConstructorErr() {
 	e := fmt.Errorf("err constructor failed")
 	s.factory.ExpectResponse(
 		mocks.NodeAdapterMock{
-			CreateFunc: func(data *pb.RequestData) (graph.Node, error) {
+			CreateFunc: func(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
 				return nil, e
 			},
 		}, nil,
@@ -84,10 +124,33 @@ func (s *GTEServerTestSuite) TestCreateNodes_ConstructorErr() {
 	s.Equal(e.Error(), response.Items[0].Base.Description)
 }
 
+func (s *GTEServerTestSuite) TestCreateNodes_InvalidArgs() {
+	s.server.nodeStorage = NewMapNodeStorage()
+	s.server.factory = adapters.NewDefaultNodeAdapterRegistry()
+
+	req, _ := GetCreateRequest(
+		[]string{"noSigma", "bigSigma", "valid"},
+		[]string{adapters.PressureLossNodeType, adapters.PressureLossNodeType, adapters.PressureLossNodeType},
+		[]map[string]float64{{}, {"sigma": 1.5}, {"sigma": 0.95}},
+	)
+	response, err := s.server.CreateNodes(nil, req)
+
+	s.Require().Nil(err)
+	s.Require().Equal(3, len(response.Items))
+	s.EqualValues(badRequest, response.Items[0].Base.Status)
+	s.EqualValues(badRequest, response.Items[1].Base.Status)
+	s.EqualValues(ok, response.Items[2].Base.Status)
+
+	updateReq, _ := GetUpdateRequest(response.Items[2].Identifiers, []map[string]float64{{"sigma": 1.5}})
+	updateResp, err := s.server.UpdateNodes(nil, updateReq)
+	s.Require().Nil(err)
+	s.EqualValues(badRequest, updateResp.Items[0].Base.Status)
+}
+
 func (s *GTEServerTestSuite) TestCreateNodes_StorageAddError() {
 	s.factory.ExpectResponse(
 		mocks.NodeAdapterMock{
-			CreateFunc: func(data *pb.RequestData) (graph.Node, error) {
+			CreateFunc: func(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
 				return graph.NewTestNode(0, 0, true, func() error {
 					return nil
 				}), nil
@@ -107,23 +170,142 @@ func (s *GTEServerTestSuite) TestCreateNodes_StorageAddError() {
 	s.EqualValues(e.Error(), response.Items[0].Base.Description)
 }
 
+func (s *GTEServerTestSuite) TestCreateNodes_NameConflict() {
+	s.server.nodeStorage = NewMapNodeStorage()
+	req, _ := GetCreateRequest(
+		[]string{"node", "node"},
+		[]string{adapters.OutletNodeType, adapters.OutletNodeType},
+		[]map[string]float64{{}, {}},
+	)
+	s.server.factory = adapters.NewDefaultNodeAdapterRegistry()
+
+	response, err := s.server.CreateNodes(nil, req)
+	s.Require().Nil(err)
+	s.Require().Equal(2, len(response.Items))
+	s.EqualValues(ok, response.Items[0].Base.Status)
+	s.EqualValues(conflict, response.Items[1].Base.Status)
+}
+
+func (s *GTEServerTestSuite) TestCreateNodes_CancelledAtomic() {
+	s.server.nodeStorage = NewMapNodeStorage()
+	ctx, cancel := context.WithCancel(context.Background())
+	// context is done after the first node is created
+	s.factory.ExpectResponse(
+		mocks.NodeAdapterMock{
+			CreateFunc: func(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+				cancel()
+				return graph.NewTestNode(0, 0, true, nil), nil
+			},
+		}, nil,
+	)
+	req, _ := GetCreateRequest(
+		[]string{"node1", "node2"},
+		[]string{"test", "test"},
+		[]map[string]float64{{}, {}},
+	)
+	req.Atomic = true
+
+	response, err := s.server.CreateNodes(ctx, req)
+
+	s.Equal(codes.Canceled, status.Code(err))
+	s.EqualValues(cancelled, response.Base.Status)
+	s.True(response.RolledBack)
+	s.Equal(0, len(s.server.nodeStorage.GetSessionIDs("")))
+}
+
+func (s *GTEServerTestSuite) TestCreateNodes_Cancelled() {
+	s.server.nodeStorage = NewMapNodeStorage()
+	ctx, cancel := context.WithCancel(context.Background())
+	s.factory.ExpectResponse(
+		mocks.NodeAdapterMock{
+			CreateFunc: func(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+				cancel()
+				return graph.NewTestNode(0, 0, true, nil), nil
+			},
+		}, nil,
+	)
+	req, _ := GetCreateRequest(
+		[]string{"node1", "node2"},
+		[]string{"test", "test"},
+		[]map[string]float64{{}, {}},
+	)
+
+	response, err := s.server.CreateNodes(ctx, req)
+
+	// status error is the only result client gets, handled items are kept
+	s.Equal(codes.Canceled, status.Code(err))
+	s.EqualValues(cancelled, response.Base.Status)
+	s.False(response.RolledBack)
+	s.Equal(1, len(s.server.nodeStorage.GetSessionIDs("")))
+}
+
 func (s *GTEServerTestSuite) TestCreateNodes_Panic() {
 	msg := "panic msg"
 	s.factory.ExpectResponse(
 		mocks.NodeAdapterMock{
-			CreateFunc: func(data *pb.RequestData) (graph.Node, error) {
+			CreateFunc: func(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
 				panic(msg)
 			},
 		}, nil,
 	)
 
 	req := s.getValidCreateRequest()
+	_, err := s.callIntercepted(req, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.server.CreateNodes(ctx, req.(*pb.NodeCreateRequest))
+	})
+
+	s.Equal(codes.Internal, status.Code(err))
+	// stack trace is not sent to the client
+	s.Equal(msg, status.Convert(err).Message())
+}
+
+func (s *GTEServerTestSuite) TestCreateNodes_AtomicRollback() {
+	s.server.nodeStorage = NewMapNodeStorage()
+	s.factory.ExpectResponse(
+		mocks.NodeAdapterMock{
+			CreateFunc: func(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+				return graph.NewTestNode(0, 0, true, nil), nil
+			},
+		}, nil,
+	)
+	s.factory.ExpectResponse(nil, fmt.Errorf("err not found"))
+
+	req, _ := GetCreateRequest(
+		[]string{"node1", "node2"},
+		[]string{"test", "test"},
+		[]map[string]float64{{}, {}},
+	)
+	req.Atomic = true
+	response, err := s.server.CreateNodes(nil, req)
+
+	s.Require().Nil(err)
+	s.True(response.RolledBack)
+	s.Require().Equal(2, len(response.Items))
+	s.EqualValues(failedDependency, response.Items[0].Base.Status)
+	s.EqualValues(notFound, response.Items[1].Base.Status)
+
+	_, getErr := s.server.nodeStorage.Get(response.Items[0].Identifiers[0])
+	s.Error(getErr)
+}
+
+func (s *GTEServerTestSuite) TestCreateNodes_AtomicSuccess() {
+	s.factory.ExpectResponse(
+		mocks.NodeAdapterMock{
+			CreateFunc: func(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+				return graph.NewTestNode(0, 0, true, nil), nil
+			},
+		}, nil,
+	)
+	s.storage.ExpectAddResponse(s.getNodeIdentifiers(1).Ids[0], nil)
+
+	req := s.getValidCreateRequest()
+	req.Atomic = true
 	response, err := s.server.CreateNodes(nil, req)
 
 	s.Require().Nil(err)
-	s.Require().Equal(0, len(response.Items))
-	s.EqualValues(internalError, response.Base.Status)
-	s.True(strings.HasPrefix(response.Base.Description, msg))
+	s.False(response.RolledBack)
+	s.Require().Equal(1, len(response.Items))
+	s.EqualValues(ok, response.Items[0].Base.Status)
 }
 
 func (s *GTEServerTestSuite) TestUpdateNodes_Success() {
@@ -224,7 +406,107 @@ func (s *GTEServerTestSuite) TestUpdateNodes_UpdaterFailed() {
 	s.EqualValues(e.Error(), response.Items[0].Base.Description)
 }
 
+func (s *GTEServerTestSuite) TestUpdateNodes_AtomicRollbackDerivedParams() {
+	s.server.nodeStorage = NewMapNodeStorage()
+	s.server.factory = adapters.NewDefaultNodeAdapterRegistry()
+
+	createReq, _ := GetCreateRequest(
+		[]string{"burner"},
+		[]string{adapters.BurnerNodeType},
+		[]map[string]float64{{"tGas": 1500, "eta": 0.99, "sigma": 0.96}},
+	)
+	createReq.Items[0].Data.SKwargs = map[string]string{"fuel": "ch4"}
+	createResp, err := s.server.CreateNodes(nil, createReq)
+	s.Require().Nil(err)
+	s.Require().EqualValues(ok, createResp.Items[0].Base.Status)
+	id := createResp.Items[0].Identifiers[0]
+
+	// unknown key makes rollback restore all the applied parameters, fuelRate reported
+	// by the state must not be restored together with tGas
+	req, _ := GetUpdateRequest(
+		[]*pb.NodeIdentifier{id, id},
+		[]map[string]float64{{"eta": 0.95, "extra": 1}, {"eta": 2}},
+	)
+	req.Atomic = true
+	response, err := s.server.UpdateNodes(nil, req)
+
+	s.Require().Nil(err)
+	s.EqualValues(ok, response.Base.Status, response.Base.Description)
+	s.True(response.RolledBack)
+
+	node, _ := s.server.nodeStorage.Get(id)
+	adapter, _ := s.server.factory.GetAdapter(adapters.BurnerNodeType)
+	state, _ := adapter.GetState(node.Node, nil)
+	s.InDelta(0.99, state.State.NumValues["eta"], 1e-9)
+	s.InDelta(1500, state.State.NumValues["tGas"], 1e-9)
+}
+
+func (s *GTEServerTestSuite) TestUpdateNodes_AtomicRollbackOutdatedApplied() {
+	compressorMap, err := adapters.NewTableCompressorMap([]float64{2, 10}, []float64{0.9, 0.8})
+	s.Require().Nil(err)
+	s.server.nodeStorage = NewMapNodeStorage()
+	s.server.factory = adapters.NewConfiguredNodeAdapterRegistry(adapters.RegistryConfig{
+		CompressorMaps: map[string]adapters.CompressorMap{"m": compressorMap},
+	})
+
+	createReq, _ := GetCreateRequest(
+		[]string{"compressor"},
+		[]string{adapters.CompressorNodeType},
+		[]map[string]float64{{"pi": 7, "eta": 0.85}},
+	)
+	createResp, err := s.server.CreateNodes(nil, createReq)
+	s.Require().Nil(err)
+	id := createResp.Items[0].Identifiers[0]
+
+	// map changes eta, so eta of the create data is outdated
+	mapResp, err := s.server.UpdateNodes(nil, &pb.NodeUpdateRequest{
+		Items: []*pb.NodeUpdateRequest_UnitRequest{
+			{Identifier: id, Data: &pb.RequestData{SKwargs: map[string]string{"map": "m"}}},
+		},
+	})
+	s.Require().Nil(err)
+	s.Require().EqualValues(ok, mapResp.Items[0].Base.Status)
+
+	req, _ := GetUpdateRequest([]*pb.NodeIdentifier{id, id}, []map[string]float64{{"eta": 0.7}, {"eta": 2}})
+	req.Atomic = true
+	response, err := s.server.UpdateNodes(nil, req)
+	s.Require().Nil(err)
+	s.True(response.RolledBack)
+
+	node, _ := s.server.nodeStorage.Get(id)
+	s.InDelta(0.8375, node.Node.(constructive.CompressorNode).Eta(), 1e-9)
+}
+
+func (s *GTEServerTestSuite) TestUpdateNodes_AtomicRollback() {
+	adapter := adapters.NewPressureLossAdapter()
+	node, _ := adapter.Create(&pb.RequestData{DKwargs: map[string]float64{"sigma": 0.9}}, nil)
+
+	s.factory.ExpectResponse(adapter, nil)
+	s.factory.ExpectResponse(adapter, nil)
+	s.storage.ExpectGetResponse(&adapters.TypedNode{NodeType: adapters.PressureLossNodeType, Node: node}, nil)
+	s.storage.ExpectGetResponse(&adapters.TypedNode{NodeType: adapters.PressureLossNodeType, Node: node}, nil)
+
+	ids := s.getNodeIdentifiers(1, 1)
+	req, _ := GetUpdateRequest(ids.Ids, []map[string]float64{{"sigma": 0.8}, {"sigma": 2}})
+	req.Atomic = true
+	response, err := s.server.UpdateNodes(nil, req)
+
+	s.Require().Nil(err)
+	s.True(response.RolledBack)
+	s.Require().Equal(2, len(response.Items))
+	s.EqualValues(failedDependency, response.Items[0].Base.Status)
+	s.EqualValues(badRequest, response.Items[1].Base.Status)
+
+	state, _ := adapter.GetState(node, nil)
+	s.InDelta(0.9, state.State.NumValues["sigma"], 1e-9)
+}
+
 func (s *GTEServerTestSuite) TestDeleteNodes_Success() {
+	node := graph.NewTestNode(1, 0, true, nil)
+	otherNode := graph.NewTestNode(0, 1, true, nil)
+	graph.Link(node.GetPorts()[0], otherNode.GetPorts()[0])
+
+	s.storage.ExpectGetResponse(&adapters.TypedNode{NodeType: "test", Node: node}, nil)
 	s.storage.ExpectDropResponse(nil)
 
 	ids := s.getNodeIdentifiers(1)
@@ -234,9 +516,25 @@ func (s *GTEServerTestSuite) TestDeleteNodes_Success() {
 	s.Require().Equal(1, len(response.Items))
 	s.EqualValues(ok, response.Items[0].Base.Status)
 	s.EqualValues(1, response.Items[0].Identifiers[0].Id)
+
+	s.Nil(node.GetPorts()[0].GetLinkPort())
+	s.Nil(otherNode.GetPorts()[0].GetLinkPort())
 }
 
 func (s *GTEServerTestSuite) TestDelete_NotFound() {
+	s.storage.ExpectGetResponse(nil, fmt.Errorf("err"))
+
+	ids := s.getNodeIdentifiers(1)
+	response, err := s.server.DeleteNodes(nil, ids)
+
+	s.Require().Nil(err)
+	s.Require().Equal(1, len(response.Items))
+
+	s.EqualValues(notFound, response.Items[0].Base.Status)
+}
+
+func (s *GTEServerTestSuite) TestDelete_DropError() {
+	s.storage.ExpectGetResponse(&adapters.TypedNode{NodeType: "test", Node: graph.NewTestNode(0, 0, true, nil)}, nil)
 	s.storage.ExpectDropResponse(fmt.Errorf("err"))
 
 	ids := s.getNodeIdentifiers(1)
@@ -248,6 +546,327 @@ func (s *GTEServerTestSuite) TestDelete_NotFound() {
 	s.EqualValues(notFound, response.Items[0].Base.Status)
 }
 
+func (s *GTEServerTestSuite) TestDeleteSession_Success() {
+	s.server.nodeStorage = NewMapNodeStorage()
+	nodeA := graph.NewTestNode(1, 0, true, nil)
+	nodeB := graph.NewTestNode(0, 1, true, nil)
+	graph.Link(nodeA.GetPorts()[0], nodeB.GetPorts()[0])
+
+	idA1, _ := s.server.nodeStorage.Add("a", adapters.NewTypedNode(nodeA, "test"))
+	idA2, _ := s.server.nodeStorage.Add("a", adapters.NewTypedNode(nodeB, "test"))
+	idB, _ := s.server.nodeStorage.Add("b", adapters.NewTypedNode(graph.NewTestNode(0, 0, true, nil), "test"))
+
+	response, err := s.server.DeleteSession(nil, &pb.SessionIdentifier{Session: "a"})
+
+	s.Require().Nil(err)
+	s.Require().Equal(2, len(response.Items))
+	s.EqualValues(ok, response.Items[0].Base.Status)
+	s.Equal(idA1, response.Items[0].Identifiers[0])
+	s.Equal(idA2, response.Items[1].Identifiers[0])
+
+	_, getErr := s.server.nodeStorage.Get(idA1)
+	s.Error(getErr)
+	_, getErr = s.server.nodeStorage.Get(idB)
+	s.Nil(getErr)
+	s.Nil(nodeB.GetPorts()[0].GetLinkPort())
+}
+
+func (s *GTEServerTestSuite) TestDeleteSession_Empty() {
+	s.storage.ExpectSessionIDsResponse(nil)
+
+	response, err := s.server.DeleteSession(nil, &pb.SessionIdentifier{Session: "a"})
+
+	s.Require().Nil(err)
+	s.EqualValues(ok, response.Base.Status)
+	s.Equal(0, len(response.Items))
+}
+
+func (s *GTEServerTestSuite) TestRenewSession_LeasesDisabled() {
+	s.server.nodeStorage = NewMapNodeStorage()
+	s.server.nodeStorage.Add("a", adapters.NewTypedNode(graph.NewTestNode(0, 0, true, nil), "test"))
+
+	response, err := s.server.RenewSession(nil, &pb.SessionIdentifier{Session: "a"})
+	s.Require().Nil(err)
+	s.EqualValues(ok, response.Base.Status)
+	s.EqualValues(0, response.LeaseMillis)
+
+	response, err = s.server.RenewSession(nil, &pb.SessionIdentifier{Session: "b"})
+	s.EqualValues(notFound, common.GetCode(err, ok))
+	s.EqualValues(notFound, response.Base.Status)
+}
+
+func (s *GTEServerTestSuite) TestRenewSession_NotFound() {
+	now := time.Unix(0, 0)
+	s.server.leases = newLeaseTracker(time.Minute)
+	s.server.leases.now = func() time.Time {
+		return now
+	}
+	s.server.nodeStorage = NewMapNodeStorage()
+	s.server.factory = adapters.NewDefaultNodeAdapterRegistry()
+
+	// failed creation does not start the session
+	req, _ := GetCreateRequest([]string{"loss"}, []string{adapters.PressureLossNodeType}, []map[string]float64{{}})
+	req.Session = "a"
+	s.server.CreateNodes(nil, req)
+	_, err := s.server.RenewSession(nil, &pb.SessionIdentifier{Session: "a"})
+	s.EqualValues(notFound, common.GetCode(err, ok))
+	s.NotContains(s.server.leases.deadlines, "a")
+
+	req, _ = GetCreateRequest([]string{"loss"}, []string{adapters.PressureLossNodeType}, []map[string]float64{{"sigma": 0.9}})
+	req.Session = "a"
+	createResp, err := s.server.CreateNodes(nil, req)
+	s.Require().Nil(err)
+	response, err := s.server.RenewSession(nil, &pb.SessionIdentifier{Session: "a"})
+	s.Require().Nil(err)
+	s.EqualValues(ok, response.Base.Status)
+
+	// expired lease is not renewed even if the reaper has not deleted the session yet
+	now = now.Add(2 * time.Minute)
+	response, err = s.server.RenewSession(nil, &pb.SessionIdentifier{Session: "a"})
+	s.EqualValues(notFound, common.GetCode(err, ok))
+	s.EqualValues(notFound, response.Base.Status)
+
+	// lease of the session is dropped with its last node
+	s.server.leases.renew("a")
+	s.server.DeleteNodes(nil, &pb.NodeIdentifiers{Ids: createResp.Items[0].Identifiers})
+	s.NotContains(s.server.leases.deadlines, "a")
+}
+
+func (s *GTEServerTestSuite) TestReapExpired() {
+	now := time.Unix(0, 0)
+	s.server.leases = newLeaseTracker(time.Minute)
+	s.server.leases.now = func() time.Time {
+		return now
+	}
+	s.server.nodeStorage = NewMapNodeStorage()
+
+	nodeA := graph.NewTestNode(1, 0, true, nil)
+	nodeB := graph.NewTestNode(0, 1, true, nil)
+	graph.Link(nodeA.GetPorts()[0], nodeB.GetPorts()[0])
+	idA, _ := s.server.nodeStorage.Add("a", adapters.NewTypedNode(nodeA, "test"))
+	idB, _ := s.server.nodeStorage.Add("b", adapters.NewTypedNode(nodeB, "test"))
+
+	s.server.leases.renew("a")
+	s.server.leases.renew("b")
+
+	response, err := s.server.RenewSession(nil, &pb.SessionIdentifier{Session: "a"})
+	s.Require().Nil(err)
+	s.EqualValues(time.Minute/time.Millisecond, response.LeaseMillis)
+
+	now = now.Add(45 * time.Second)
+	s.server.RenewSession(nil, &pb.SessionIdentifier{Session: "b"})
+	now = now.Add(45 * time.Second)
+	s.server.reapExpired()
+
+	_, getErr := s.server.nodeStorage.Get(idA)
+	s.Error(getErr)
+	_, getErr = s.server.nodeStorage.Get(idB)
+	s.Nil(getErr)
+	s.Nil(nodeB.GetPorts()[0].GetLinkPort())
+}
+
+func (s *GTEServerTestSuite) TestReapExpired_Panic() {
+	buf := &bytes.Buffer{}
+	s.server.logger = log.New(buf, "", 0)
+	s.server.leases = newLeaseTracker(time.Minute)
+	now := time.Unix(0, 0)
+	s.server.leases.now = func() time.Time {
+		return now
+	}
+	s.server.nodeStorage = NewMapNodeStorage()
+
+	idA, _ := s.server.nodeStorage.Add("a", adapters.NewTypedNode(panicPortsNode{graph.NewTestNode(0, 0, true, nil)}, "test"))
+	idB, _ := s.server.nodeStorage.Add("b", adapters.NewTypedNode(graph.NewTestNode(0, 0, true, nil), "test"))
+	s.server.leases.renew("a")
+	s.server.leases.renew("b")
+
+	now = now.Add(2 * time.Minute)
+	s.NotPanics(s.server.reapExpired)
+	s.Contains(buf.String(), "reaper session=a panic=\"ports are broken\"")
+
+	// panic of one session neither stops reaping of the others nor leaves the lock held
+	_, getErr := s.server.nodeStorage.Get(idA)
+	s.Nil(getErr)
+	_, getErr = s.server.nodeStorage.Get(idB)
+	s.Error(getErr)
+	_, err := s.server.DeleteNodes(nil, &pb.NodeIdentifiers{})
+	s.Nil(err)
+}
+
+func (s *GTEServerTestSuite) TestReapExpired_ConcurrentLink() {
+	s.server.leases = newLeaseTracker(time.Minute)
+	now := time.Unix(0, 0)
+	s.server.leases.now = func() time.Time {
+		return now
+	}
+	s.server.nodeStorage = NewMapNodeStorage()
+	s.server.factory = adapters.NewDefaultNodeAdapterRegistry()
+
+	req, _ := GetCreateRequest(
+		[]string{"inlet", "outlet"},
+		[]string{adapters.InletNodeType, adapters.OutletNodeType},
+		[]map[string]float64{{"tStag": 288, "pStag": 1e5}, {}},
+	)
+	req.Session = "a"
+	createResp, err := s.server.CreateNodes(nil, req)
+	s.Require().Nil(err)
+	s.Require().EqualValues(ok, createResp.Base.Status)
+	inlet, outlet := createResp.Items[0].Identifiers[0], createResp.Items[1].Identifiers[0]
+	now = now.Add(2 * time.Minute)
+
+	// reaper unlinks ports of the session while it is being linked, run with -race to check it
+	linked, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; ; i++ {
+			resp, _ := s.server.Link(nil, &pb.LinkRequest{
+				Items: []*pb.LinkRequest_UnitRequest{
+					{
+						Id1: &pb.PortIdentifier{NodeIdentifier: inlet, PortTag: "gas_output"},
+						Id2: &pb.PortIdentifier{NodeIdentifier: outlet, PortTag: "gas_input"},
+					},
+				},
+			})
+			if i == 0 {
+				close(linked)
+			}
+			if resp.Items[0].Base.Status != ok {
+				return
+			}
+		}
+	}()
+	<-linked
+	s.server.reapExpired()
+	<-done
+
+	_, getErr := s.server.nodeStorage.Get(inlet)
+	s.Error(getErr)
+	_, getErr = s.server.nodeStorage.Get(outlet)
+	s.Error(getErr)
+}
+
+func (s *GTEServerTestSuite) TestLeasedServer() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := NewLeasedGTEServer(ctx, nil, LeaseConfig{
+		Duration:       10 * time.Millisecond,
+		ReaperInterval: 5 * time.Millisecond,
+	}).(*gteServer)
+
+	req, _ := GetCreateRequest(
+		[]string{"node"},
+		[]string{adapters.OutletNodeType},
+		[]map[string]float64{{}},
+	)
+	req.Session = "a"
+	response, err := server.CreateNodes(nil, req)
+	s.Require().Nil(err)
+	s.Require().EqualValues(ok, response.Items[0].Base.Status)
+
+	id := response.Items[0].Identifiers[0]
+	s.Eventually(func() bool {
+		_, getErr := server.nodeStorage.Get(id)
+		return getErr != nil
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (s *GTEServerTestSuite) TestLeasedServer_RestoredSessions() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// nodes restored by persistent storage are not created through the server
+	storage := NewMapNodeStorage()
+	id, err := storage.Add("a", adapters.NewTypedNode(graph.NewTestNode(0, 0, true, nil), "test"))
+	s.Require().Nil(err)
+
+	NewConfiguredGTEServer(ctx, GTEServerConfig{
+		Storage: storage,
+		Lease: &LeaseConfig{
+			Duration:       10 * time.Millisecond,
+			ReaperInterval: 5 * time.Millisecond,
+		},
+	})
+
+	s.Eventually(func() bool {
+		_, getErr := storage.Get(id)
+		return getErr != nil
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (s *GTEServerTestSuite) TestListNodes_Filters() {
+	s.server.nodeStorage = NewMapNodeStorage()
+	for _, item := range []struct {
+		session, name, nodeType string
+	}{
+		{"a", "compressor_1", "compressor"},
+		{"a", "turbine_1", "turbine"},
+		{"a", "compressor_2", "compressor"},
+		{"b", "compressor_3", "compressor"},
+	} {
+		node := graph.NewTestNode(0, 0, true, nil)
+		node.SetName(item.name)
+		s.server.nodeStorage.Add(item.session, adapters.NewTypedNode(node, item.nodeType))
+	}
+
+	response, err := s.server.ListNodes(nil, &pb.NodeListRequest{Session: "a"})
+	s.Require().Nil(err)
+	s.EqualValues(ok, response.Base.Status)
+	s.Equal([]string{"compressor_1", "turbine_1", "compressor_2"}, s.getListNames(response))
+	s.Equal("", response.NextPageToken)
+
+	response, _ = s.server.ListNodes(nil, &pb.NodeListRequest{Session: "a", NodeType: "compressor"})
+	s.Equal([]string{"compressor_1", "compressor_2"}, s.getListNames(response))
+
+	response, _ = s.server.ListNodes(nil, &pb.NodeListRequest{Session: "a", NamePrefix: "turb"})
+	s.Equal([]string{"turbine_1"}, s.getListNames(response))
+	s.Equal("turbine", response.Items[0].Identifier.NodeType)
+
+	response, _ = s.server.ListNodes(nil, &pb.NodeListRequest{Session: "c"})
+	s.Equal(0, len(response.Items))
+}
+
+func (s *GTEServerTestSuite) TestListNodes_Pagination() {
+	s.server.nodeStorage = NewMapNodeStorage()
+	for i := 0; i != 5; i++ {
+		node := graph.NewTestNode(0, 0, true, nil)
+		node.SetName(fmt.Sprintf("node_%d", i))
+		s.server.nodeStorage.Add("", adapters.NewTypedNode(node, "test"))
+	}
+
+	var names []string
+	req := &pb.NodeListRequest{PageSize: 2}
+	for pageCnt := 0; ; pageCnt++ {
+		s.Require().True(pageCnt < 3)
+
+		response, err := s.server.ListNodes(nil, req)
+		s.Require().Nil(err)
+		s.Require().EqualValues(ok, response.Base.Status)
+		s.True(len(response.Items) <= 2)
+
+		names = append(names, s.getListNames(response)...)
+		if response.NextPageToken == "" {
+			break
+		}
+		req.PageToken = response.NextPageToken
+	}
+	s.Equal([]string{"node_0", "node_1", "node_2", "node_3", "node_4"}, names)
+}
+
+func (s *GTEServerTestSuite) TestListNodes_InvalidToken() {
+	response, err := s.server.ListNodes(nil, &pb.NodeListRequest{PageToken: "invalid"})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.EqualValues(badRequest, response.Base.Status)
+}
+
+func (s *GTEServerTestSuite) getListNames(response *pb.NodeListResponse) []string {
+	result := make([]string, len(response.Items))
+	for i, item := range response.Items {
+		result[i] = item.Name
+	}
+	return result
+}
+
 func (s *GTEServerTestSuite) TestGetNodes_Success() {
 	s.factory.ExpectResponse(
 		mocks.NodeAdapterMock{
@@ -272,6 +891,46 @@ func (s *GTEServerTestSuite) TestGetNodes_Success() {
 	s.EqualValues(1, response.Items[0].Identifier.Id)
 }
 
+func (s *GTEServerTestSuite) TestGetNodes_ByName() {
+	s.storage.ExpectFindByNameResponse(&pb.NodeIdentifier{Id: 3, NodeType: "test", Session: "a"}, nil)
+	s.factory.ExpectResponse(
+		mocks.NodeAdapterMock{
+			GetStateFunc: func(node graph.Node, requiredFields []string) (*pb.NodeState, error) {
+				return &pb.NodeState{}, nil
+			},
+		}, nil,
+	)
+	s.storage.ExpectGetResponse(&adapters.TypedNode{NodeType: "test", Node: graph.NewTestNode(0, 0, true, nil)}, nil)
+
+	response, err := s.server.GetNodesState(nil, &pb.NodeStateRequest{
+		Items: []*pb.NodeStateRequest_UnitRequest{
+			{Identifier: &pb.NodeIdentifier{Name: "node", Session: "a"}},
+		},
+	})
+
+	s.Require().Nil(err)
+	s.Require().Equal(1, len(response.Items))
+	s.EqualValues(ok, response.Items[0].Base.Status)
+	s.EqualValues(3, response.Items[0].Identifier.Id)
+	s.Equal("test", response.Items[0].Identifier.NodeType)
+}
+
+func (s *GTEServerTestSuite) TestGetNodes_NameNotFound() {
+	e := fmt.Errorf("name not found")
+	s.storage.ExpectFindByNameResponse(nil, e)
+
+	response, err := s.server.GetNodesState(nil, &pb.NodeStateRequest{
+		Items: []*pb.NodeStateRequest_UnitRequest{
+			{Identifier: &pb.NodeIdentifier{Name: "node"}},
+		},
+	})
+
+	s.Require().Nil(err)
+	s.Require().Equal(1, len(response.Items))
+	s.EqualValues(notFound, response.Items[0].Base.Status)
+	s.Equal(e.Error(), response.Items[0].Base.Description)
+}
+
 func (s *GTEServerTestSuite) TestGetNodes_GetterNotFound() {
 	e := fmt.Errorf("getter not found")
 	s.factory.ExpectResponse(
@@ -352,12 +1011,13 @@ func (s *GTEServerTestSuite) TestGetNodes_Panic() {
 		}),
 	}, nil)
 
-	response, err := s.server.GetNodesState(nil, s.getValidGetStateRequest())
+	_, err := s.callIntercepted(s.getValidGetStateRequest(), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.server.GetNodesState(ctx, req.(*pb.NodeStateRequest))
+	})
 
-	s.Require().Nil(err)
-	s.Require().Equal(0, len(response.Items))
-	s.EqualValues(internalError, response.Base.Status)
-	s.True(strings.HasPrefix(response.Base.Description, msg))
+	s.Equal(codes.Internal, status.Code(err))
+	// stack trace is not sent to the client
+	s.Equal(msg, status.Convert(err).Message())
 }
 
 func (s *GTEServerTestSuite) TestProcess_Success() {
@@ -378,6 +1038,27 @@ func (s *GTEServerTestSuite) TestProcess_Success() {
 	s.EqualValues(ok, r.Items[0].Base.Status)
 }
 
+func (s *GTEServerTestSuite) TestProcess_Cancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	processCnt := 0
+	s.storage.ExpectGetResponse(
+		&adapters.TypedNode{
+			NodeType: "test",
+			Node: graph.NewTestNode(0, 0, true, func() error {
+				processCnt++
+				cancel()
+				return nil
+			}),
+		}, nil,
+	)
+
+	r, err := s.server.Process(ctx, s.getNodeIdentifiers(1, 2, 3))
+	s.Equal(codes.Canceled, status.Code(err))
+	s.EqualValues(cancelled, r.Base.Status)
+	s.Equal(0, len(r.Items))
+	s.Equal(1, processCnt)
+}
+
 func (s *GTEServerTestSuite) TestProcess_ProcessError() {
 	e := fmt.Errorf("process error")
 	s.storage.ExpectGetResponse(
@@ -413,15 +1094,216 @@ func (s *GTEServerTestSuite) TestProcess_NodeNotFound() {
 	s.EqualValues(e.Error(), r.Items[0].Base.Description)
 }
 
-func (s *GTEServerTestSuite) TestLink_Success() {
-	s.storage.ExpectGetResponse(
-		&adapters.TypedNode{
-			NodeType: "test",
-			Node: graph.NewTestNode(0, 0, true, func() error {
-				return nil
-			}),
-		}, nil,
-	)
+func (s *GTEServerTestSuite) TestProcessStream_Success() {
+	s.storage.ExpectGetResponse(&adapters.TypedNode{
+		NodeType: "test",
+		Node: graph.NewTestNode(0, 0, true, func() error {
+			time.Sleep(time.Millisecond)
+			return nil
+		}),
+	}, nil)
+	e := fmt.Errorf("err not found")
+	s.storage.ExpectGetResponse(nil, e)
+
+	stream := mocks.NewProcessStreamMock(context.Background())
+	err := s.server.ProcessStream(s.getNodeIdentifiers(1, 2), stream)
+	s.Require().Nil(err)
+
+	s.Require().Equal(2, len(stream.Items))
+	s.EqualValues(ok, stream.Items[0].Base.Status)
+	s.EqualValues(1, stream.Items[0].Identifiers[0].Id)
+	s.True(stream.Items[0].DurationMicros >= 1000)
+	s.EqualValues(notFound, stream.Items[1].Base.Status)
+	s.Equal(e.Error(), stream.Items[1].Base.Description)
+}
+
+func (s *GTEServerTestSuite) TestProcessStream_Cancel() {
+	for i := 0; i != 2; i++ {
+		s.storage.ExpectGetResponse(&adapters.TypedNode{NodeType: "test", Node: graph.NewTestNode(0, 0, true, nil)}, nil)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := mocks.NewProcessStreamMock(ctx)
+	stream.OnSend = func(item *pb.NodeModifyResponse_UnitResponse) {
+		cancel()
+	}
+	err := s.server.ProcessStream(s.getNodeIdentifiers(1, 2), stream)
+
+	s.Equal(codes.Canceled, status.Code(err))
+	s.Equal(1, len(stream.Items))
+}
+
+func (s *GTEServerTestSuite) TestProcessStream_Panic() {
+	s.storage.ExpectGetResponse(&adapters.TypedNode{
+		NodeType: "test",
+		Node: graph.NewTestNode(0, 0, true, func() error {
+			panic("process panic")
+		}),
+	}, nil)
+
+	// panic is recovered by the stream interceptor in the server
+	stream := mocks.NewProcessStreamMock(context.Background())
+	s.PanicsWithValue("process panic", func() {
+		s.server.ProcessStream(s.getNodeIdentifiers(1), stream)
+	})
+
+	// lock is released, so the following requests are not blocked
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.server.Process(nil, &pb.NodeIdentifiers{})
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("port lock is not released after panic")
+	}
+}
+
+func (s *GTEServerTestSuite) TestProcessGraph_CallOrder() {
+	var calls []string
+	first, second := s.addChainNodes(func(name string) error {
+		calls = append(calls, name)
+		return nil
+	})
+
+	r, err := s.server.ProcessGraph(nil, &pb.ProcessGraphRequest{Ids: []*pb.NodeIdentifier{second, first}})
+	s.Require().Nil(err)
+	s.EqualValues(ok, r.Base.Status, r.Base.Description)
+	s.Equal([]*pb.NodeIdentifier{first, second}, r.CallOrder)
+	s.Equal([]string{"first", "second"}, calls)
+	s.EqualValues(1, r.Iterations)
+	s.False(r.Converged)
+}
+
+func (s *GTEServerTestSuite) TestProcessGraph_Iterate() {
+	var source graph.Node
+	value := 1.
+	first, second := s.addChainNodes(func(name string) error {
+		if name == "first" {
+			value /= 2
+			source.GetPorts()[0].SetState(graph.NewNumberPortState(value))
+		}
+		return nil
+	})
+	node, _ := s.server.nodeStorage.Get(first)
+	source = node.Node
+
+	r, err := s.server.ProcessGraph(nil, &pb.ProcessGraphRequest{
+		Ids: []*pb.NodeIdentifier{first, second}, Iterate: true, Precision: 1e-2,
+	})
+	s.Require().Nil(err)
+	s.EqualValues(ok, r.Base.Status, r.Base.Description)
+	s.True(r.Converged)
+	s.EqualValues(7, r.Iterations)
+
+	r, err = s.server.ProcessGraph(nil, &pb.ProcessGraphRequest{
+		Ids: []*pb.NodeIdentifier{first, second}, Iterate: true, Precision: 1e-9, MaxIterations: 3,
+	})
+	s.Require().Nil(err)
+	s.EqualValues(ok, r.Base.Status)
+	s.False(r.Converged)
+	s.EqualValues(3, r.Iterations)
+	s.Equal(1, len(r.Base.Messages))
+}
+
+func (s *GTEServerTestSuite) TestProcessGraph_ProcessError() {
+	first, second := s.addChainNodes(func(name string) error {
+		if name == "second" {
+			return fmt.Errorf("process error")
+		}
+		return nil
+	})
+
+	r, err := s.server.ProcessGraph(nil, &pb.ProcessGraphRequest{Ids: []*pb.NodeIdentifier{first, second}})
+	s.Equal(codes.Internal, status.Code(err))
+	s.EqualValues(internalError, r.Base.Status)
+	s.Equal("failed to process node second: process error", r.Base.Description)
+	s.Equal([]*pb.NodeIdentifier{first, second}, r.CallOrder)
+}
+
+func (s *GTEServerTestSuite) TestProcessGraph_Deadline() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	first, second := s.addChainNodes(func(name string) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	r, err := s.server.ProcessGraph(ctx, &pb.ProcessGraphRequest{
+		Ids: []*pb.NodeIdentifier{first, second}, Iterate: true,
+	})
+	s.Equal(codes.DeadlineExceeded, status.Code(err))
+	s.EqualValues(deadlineExceeded, r.Base.Status)
+	s.EqualValues(1, r.Iterations)
+}
+
+func (s *GTEServerTestSuite) TestProcessGraph_Cycle() {
+	first, second := s.addChainNodes(nil)
+	firstNode, _ := s.server.nodeStorage.Get(first)
+	secondNode, _ := s.server.nodeStorage.Get(second)
+	graph.Link(secondNode.Node.GetPorts()[1], firstNode.Node.GetPorts()[0])
+
+	r, err := s.server.ProcessGraph(nil, &pb.ProcessGraphRequest{Ids: []*pb.NodeIdentifier{first, second}})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.EqualValues(badRequest, r.Base.Status)
+}
+
+func (s *GTEServerTestSuite) TestProcessGraph_NodeNotFound() {
+	e := fmt.Errorf("err not found")
+	s.storage.ExpectGetResponse(nil, e)
+
+	r, err := s.server.ProcessGraph(nil, &pb.ProcessGraphRequest{Ids: s.getNodeIdentifiers(1).Ids})
+	s.Equal(codes.NotFound, status.Code(err))
+	s.EqualValues(notFound, r.Base.Status)
+	s.Equal(e.Error(), r.Base.Description)
+}
+
+func (s *GTEServerTestSuite) TestProcessGraph_Duplicate() {
+	first, _ := s.addChainNodes(nil)
+
+	r, err := s.server.ProcessGraph(nil, &pb.ProcessGraphRequest{Ids: []*pb.NodeIdentifier{first, first}})
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.EqualValues(badRequest, r.Base.Status)
+}
+
+// addChainNodes stores two nodes named "first" and "second". Update port of the first node
+// is linked to require port of the second one. process is called with name of the processed node
+func (s *GTEServerTestSuite) addChainNodes(process func(name string) error) (*pb.NodeIdentifier, *pb.NodeIdentifier) {
+	s.server.nodeStorage = NewMapNodeStorage()
+
+	ids := make([]*pb.NodeIdentifier, 2)
+	nodes := make([]graph.Node, 2)
+	for i, name := range []string{"first", "second"} {
+		name := name
+		node := graph.NewTestNode(1, 1, true, func() error {
+			if process == nil {
+				return nil
+			}
+			return process(name)
+		})
+		node.SetName(name)
+
+		id, err := s.server.nodeStorage.Add("", adapters.NewTypedNode(node, "test"))
+		s.Require().Nil(err)
+		ids[i], nodes[i] = id, node
+	}
+
+	firstUpdatePorts, _ := nodes[0].GetUpdatePorts()
+	secondRequirePorts, _ := nodes[1].GetRequirePorts()
+	graph.Link(firstUpdatePorts[0], secondRequirePorts[0])
+	return ids[0], ids[1]
+}
+
+func (s *GTEServerTestSuite) TestLink_Success() {
+	s.storage.ExpectGetResponse(
+		&adapters.TypedNode{
+			NodeType: "test",
+			Node: graph.NewTestNode(0, 0, true, func() error {
+				return nil
+			}),
+		}, nil,
+	)
 
 	s.storage.ExpectGetResponse(
 		&adapters.TypedNode{
@@ -457,6 +1339,38 @@ func (s *GTEServerTestSuite) TestLink_Success() {
 	s.EqualValues(2, r.Items[0].Identifiers[1].Id)
 }
 
+func (s *GTEServerTestSuite) TestLink_ByNodeName() {
+	s.server.nodeStorage = NewMapNodeStorage()
+	s.server.factory = adapters.NewDefaultNodeAdapterRegistry()
+	req, _ := GetCreateRequest(
+		[]string{"inlet", "outlet"},
+		[]string{adapters.InletNodeType, adapters.OutletNodeType},
+		[]map[string]float64{{"tStag": 288, "pStag": 1e5}, {}},
+	)
+	req.Session = "a"
+	createResp, err := s.server.CreateNodes(nil, req)
+	s.Require().Nil(err)
+	s.Require().EqualValues(ok, createResp.Base.Status)
+
+	r, err := s.server.Link(nil, &pb.LinkRequest{
+		Items: []*pb.LinkRequest_UnitRequest{
+			{
+				Id1: &pb.PortIdentifier{
+					NodeIdentifier: &pb.NodeIdentifier{Session: "a"}, NodeName: "inlet", PortTag: "pressure_output",
+				},
+				Id2: &pb.PortIdentifier{
+					NodeIdentifier: &pb.NodeIdentifier{Session: "a"}, NodeName: "outlet", PortTag: "pressure_input",
+				},
+			},
+		},
+	})
+	s.Require().Nil(err)
+	s.Require().Equal(1, len(r.Items))
+	s.EqualValues(ok, r.Items[0].Base.Status, r.Items[0].Base.Description)
+	s.Equal(createResp.Items[0].Identifiers[0], r.Items[0].Identifiers[0])
+	s.Equal(createResp.Items[1].Identifiers[0], r.Items[0].Identifiers[1])
+}
+
 func (s *GTEServerTestSuite) TestLink_NodeNotFound() {
 	e := fmt.Errorf("err not found")
 	s.storage.ExpectGetResponse(nil, e)
@@ -517,6 +1431,386 @@ func (s *GTEServerTestSuite) TestLink_PortGetterError() {
 	s.EqualValues(e.Error(), r.Items[0].Base.Description)
 }
 
+func (s *GTEServerTestSuite) TestLink_DifferentSessions() {
+	req := s.getValidLinkRequest()
+	req.Items[0].Id2.NodeIdentifier.Session = "other"
+
+	r, err := s.server.Link(nil, req)
+	s.Require().Nil(err)
+
+	s.Require().Equal(1, len(r.Items))
+	s.EqualValues(badRequest, r.Items[0].Base.Status)
+}
+
+func (s *GTEServerTestSuite) TestLink_AtomicRollback() {
+	port1, port2 := s.expectLinkPorts()
+	prevLink := graph.NewAttachedPort(nil)
+	graph.Link(port1, prevLink)
+
+	e := fmt.Errorf("err not found")
+	s.storage.ExpectGetResponse(nil, e)
+
+	req := s.getValidLinkRequest()
+	req.Items = append(req.Items, req.Items[0])
+	req.Atomic = true
+	r, err := s.server.Link(nil, req)
+	s.Require().Nil(err)
+
+	s.True(r.RolledBack)
+	s.Require().Equal(2, len(r.Items))
+	s.EqualValues(failedDependency, r.Items[0].Base.Status)
+	s.EqualValues(notFound, r.Items[1].Base.Status)
+
+	s.Equal(prevLink, port1.GetLinkPort())
+	s.Nil(port2.GetLinkPort())
+}
+
+func (s *GTEServerTestSuite) TestUnlink_Success() {
+	port1, port2 := s.expectLinkPorts()
+	graph.Link(port1, port2)
+
+	r, err := s.server.Unlink(nil, s.getValidLinkRequest())
+	s.Require().Nil(err)
+
+	s.Require().Equal(1, len(r.Items))
+	s.EqualValues(ok, r.Items[0].Base.Status)
+	s.EqualValues(1, r.Items[0].Identifiers[0].Id)
+	s.EqualValues(2, r.Items[0].Identifiers[1].Id)
+
+	s.Nil(port1.GetLinkPort())
+	s.Nil(port2.GetLinkPort())
+}
+
+func (s *GTEServerTestSuite) TestUnlink_WeakLinks() {
+	for _, linkType := range []pb.LinkType{pb.LinkType_WEAK_FIRST, pb.LinkType_WEAK_SECOND, pb.LinkType_WEAK_BOTH} {
+		req, inlet, outlet := s.linkInletOutlet(linkType)
+
+		r, err := s.server.Unlink(nil, req)
+		s.Require().Nil(err)
+		s.Require().Equal(1, len(r.Items))
+		s.EqualValues(ok, r.Items[0].Base.Status, "%v: %s", linkType, r.Items[0].Base.Description)
+
+		s.Nil(s.getStoredPort(inlet, "pressure_output").GetLinkPort(), "%v", linkType)
+		s.Nil(s.getStoredPort(outlet, "pressure_input").GetLinkPort(), "%v", linkType)
+		s.Equal(0, len(s.getStoredNode(inlet).WeakPorts), "%v", linkType)
+		s.Equal(0, len(s.getStoredNode(outlet).WeakPorts), "%v", linkType)
+	}
+}
+
+func (s *GTEServerTestSuite) TestDeleteNodes_WeakLinks() {
+	for _, linkType := range []pb.LinkType{pb.LinkType_WEAK_FIRST, pb.LinkType_WEAK_SECOND, pb.LinkType_WEAK_BOTH} {
+		_, inlet, outlet := s.linkInletOutlet(linkType)
+		outletPort := s.getStoredPort(outlet, "pressure_input")
+
+		r, err := s.server.DeleteNodes(nil, &pb.NodeIdentifiers{Ids: []*pb.NodeIdentifier{inlet}})
+		s.Require().Nil(err)
+		s.EqualValues(ok, r.Items[0].Base.Status)
+
+		// surviving port refers neither to the deleted port nor to its weak wrapper
+		s.Nil(outletPort.GetLinkPort(), "%v", linkType)
+	}
+}
+
+// linkInletOutlet creates inlet and outlet linked by pressure ports with linkType
+// and returns the link request with identifiers of the nodes
+func (s *GTEServerTestSuite) linkInletOutlet(linkType pb.LinkType) (*pb.LinkRequest, *pb.NodeIdentifier, *pb.NodeIdentifier) {
+	s.server.nodeStorage = NewMapNodeStorage()
+	s.server.factory = adapters.NewDefaultNodeAdapterRegistry()
+	createReq, _ := GetCreateRequest(
+		[]string{"inlet", "outlet"},
+		[]string{adapters.InletNodeType, adapters.OutletNodeType},
+		[]map[string]float64{{"tStag": 288, "pStag": 1e5}, {}},
+	)
+	createResp, err := s.server.CreateNodes(nil, createReq)
+	s.Require().Nil(err)
+	inlet, outlet := createResp.Items[0].Identifiers[0], createResp.Items[1].Identifiers[0]
+
+	req := &pb.LinkRequest{
+		Items: []*pb.LinkRequest_UnitRequest{
+			{
+				LinkType: linkType,
+				Id1:      &pb.PortIdentifier{NodeIdentifier: inlet, PortTag: "pressure_output"},
+				Id2:      &pb.PortIdentifier{NodeIdentifier: outlet, PortTag: "pressure_input"},
+			},
+		},
+	}
+	linkResp, err := s.server.Link(nil, req)
+	s.Require().Nil(err)
+	s.Require().EqualValues(ok, linkResp.Items[0].Base.Status)
+	return req, inlet, outlet
+}
+
+func (s *GTEServerTestSuite) getStoredNode(id *pb.NodeIdentifier) *adapters.TypedNode {
+	node, err := s.server.nodeStorage.Get(id)
+	s.Require().Nil(err)
+	return node
+}
+
+func (s *GTEServerTestSuite) getStoredPort(id *pb.NodeIdentifier, tag string) graph.Port {
+	_, port, err := s.server.getPort(&pb.PortIdentifier{NodeIdentifier: id, PortTag: tag})
+	s.Require().Nil(err)
+	return port
+}
+
+func (s *GTEServerTestSuite) TestUnlink_NotLinked() {
+	port1, port2 := s.expectLinkPorts()
+	graph.Link(port1, graph.NewAttachedPort(nil))
+
+	r, err := s.server.Unlink(nil, s.getValidLinkRequest())
+	s.Require().Nil(err)
+
+	s.Require().Equal(1, len(r.Items))
+	s.EqualValues(badRequest, r.Items[0].Base.Status)
+	s.NotNil(port1.GetLinkPort())
+	s.Nil(port2.GetLinkPort())
+}
+
+func (s *GTEServerTestSuite) TestUnlink_NodeNotFound() {
+	e := fmt.Errorf("err not found")
+	s.storage.ExpectGetResponse(nil, e)
+
+	r, err := s.server.Unlink(nil, s.getValidLinkRequest())
+	s.Require().Nil(err)
+
+	s.Require().Equal(1, len(r.Items))
+	s.EqualValues(notFound, r.Items[0].Base.Status)
+	s.EqualValues(e.Error(), r.Items[0].Base.Description)
+}
+
+// expectLinkPorts sets up storage and factory to return two ports of different nodes
+func (s *GTEServerTestSuite) expectLinkPorts() (graph.Port, graph.Port) {
+	ports := make([]graph.Port, 2)
+	for i := range ports {
+		node := graph.NewTestNode(0, 0, true, nil)
+		port := graph.NewAttachedPort(node)
+		ports[i] = port
+
+		s.storage.ExpectGetResponse(&adapters.TypedNode{NodeType: "test", Node: node}, nil)
+		s.factory.ExpectResponse(
+			mocks.NodeAdapterMock{
+				GetPortFunc: func(tag string, node graph.Node) (graph.Port, error) {
+					return port, nil
+				},
+			}, nil,
+		)
+	}
+	return ports[0], ports[1]
+}
+
+func (s *GTEServerTestSuite) TestGetPortsState_Success() {
+	node := graph.NewTestNode(0, 0, true, nil)
+	port := graph.NewAttachedPort(node)
+	port.SetState(states.NewTemperaturePortState(300))
+
+	s.storage.ExpectGetResponse(&adapters.TypedNode{NodeType: "test", Node: node}, nil)
+	s.factory.ExpectResponse(
+		mocks.NodeAdapterMock{
+			GetPortFunc: func(tag string, node graph.Node) (graph.Port, error) {
+				return port, nil
+			},
+		}, nil,
+	)
+
+	r, err := s.server.GetPortsState(nil, s.getValidPortStateRequest())
+	s.Require().Nil(err)
+
+	s.Require().Equal(1, len(r.Items))
+	s.EqualValues(ok, r.Items[0].Base.Status)
+	s.Equal("port", r.Items[0].State.Tag)
+	s.InDelta(300, r.Items[0].State.State.NumValues["tStag"], 1e-9)
+}
+
+func (s *GTEServerTestSuite) TestGetPortsState_Cancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r, err := s.server.GetPortsState(ctx, s.getValidPortStateRequest())
+	s.Equal(codes.Canceled, status.Code(err))
+	s.EqualValues(cancelled, r.Base.Status)
+	s.Equal(0, len(r.Items))
+}
+
+func (s *GTEServerTestSuite) TestGetPortsState_NodeNotFound() {
+	e := fmt.Errorf("err not found")
+	s.storage.ExpectGetResponse(nil, e)
+
+	r, err := s.server.GetPortsState(nil, s.getValidPortStateRequest())
+	s.Require().Nil(err)
+
+	s.Require().Equal(1, len(r.Items))
+	s.EqualValues(notFound, r.Items[0].Base.Status)
+	s.EqualValues(e.Error(), r.Items[0].Base.Description)
+}
+
+func (s *GTEServerTestSuite) TestGetPortsState_StateNotSet() {
+	node := graph.NewTestNode(0, 0, true, nil)
+
+	s.storage.ExpectGetResponse(&adapters.TypedNode{NodeType: "test", Node: node}, nil)
+	s.factory.ExpectResponse(
+		mocks.NodeAdapterMock{
+			GetPortFunc: func(tag string, node graph.Node) (graph.Port, error) {
+				return graph.NewAttachedPort(node), nil
+			},
+		}, nil,
+	)
+
+	r, err := s.server.GetPortsState(nil, s.getValidPortStateRequest())
+	s.Require().Nil(err)
+
+	s.Require().Equal(1, len(r.Items))
+	s.EqualValues(internalError, r.Items[0].Base.Status)
+}
+
+func (s *GTEServerTestSuite) TestGetPortsState_Panic() {
+	msg := "panic msg"
+	s.storage.ExpectGetResponse(&adapters.TypedNode{
+		NodeType: "test",
+		Node:     graph.NewTestNode(0, 0, true, nil),
+	}, nil)
+	s.factory.ExpectResponse(
+		mocks.NodeAdapterMock{
+			GetPortFunc: func(tag string, node graph.Node) (graph.Port, error) {
+				panic(msg)
+			},
+		}, nil,
+	)
+
+	_, err := s.callIntercepted(s.getValidPortStateRequest(), func(ctx context.Context, req interface{}) (interface{}, error) {
+		return s.server.GetPortsState(ctx, req.(*pb.PortStateRequest))
+	})
+	s.Equal(codes.Internal, status.Code(err))
+
+	// stack trace is not sent to the client
+	s.Equal(msg, status.Convert(err).Message())
+}
+
+func (s *GTEServerTestSuite) TestSetPortsState_Success() {
+	node := graph.NewTestNode(0, 0, true, nil)
+	port := graph.NewAttachedPort(node)
+
+	s.storage.ExpectGetResponse(&adapters.TypedNode{NodeType: "test", Node: node}, nil)
+	s.factory.ExpectResponse(
+		mocks.NodeAdapterMock{
+			GetPortFunc: func(tag string, node graph.Node) (graph.Port, error) {
+				return port, nil
+			},
+		}, nil,
+	)
+
+	r, err := s.server.SetPortsState(nil, s.getValidPortUpdateRequest())
+	s.Require().Nil(err)
+
+	s.Require().Equal(1, len(r.Items))
+	s.EqualValues(ok, r.Items[0].Base.Status)
+	s.Equal("port", r.Items[0].Identifier.PortTag)
+	s.Equal(states.NewTemperaturePortState(300), port.GetState())
+}
+
+func (s *GTEServerTestSuite) TestSetPortsState_PortNotFound() {
+	e := fmt.Errorf("port not found")
+	s.storage.ExpectGetResponse(&adapters.TypedNode{
+		NodeType: "test",
+		Node:     graph.NewTestNode(0, 0, true, nil),
+	}, nil)
+	s.factory.ExpectResponse(
+		mocks.NodeAdapterMock{
+			GetPortFunc: func(tag string, node graph.Node) (graph.Port, error) {
+				return nil, e
+			},
+		}, nil,
+	)
+
+	r, err := s.server.SetPortsState(nil, s.getValidPortUpdateRequest())
+	s.Require().Nil(err)
+
+	s.Require().Equal(1, len(r.Items))
+	s.EqualValues(notFound, r.Items[0].Base.Status)
+	s.EqualValues(e.Error(), r.Items[0].Base.Description)
+}
+
+func (s *GTEServerTestSuite) TestSetPortsState_InvalidState() {
+	node := graph.NewTestNode(0, 0, true, nil)
+	port := graph.NewAttachedPort(node)
+
+	s.storage.ExpectGetResponse(&adapters.TypedNode{NodeType: "test", Node: node}, nil)
+	s.factory.ExpectResponse(
+		mocks.NodeAdapterMock{
+			GetPortFunc: func(tag string, node graph.Node) (graph.Port, error) {
+				return port, nil
+			},
+		}, nil,
+	)
+
+	req := s.getValidPortUpdateRequest()
+	req.Items[0].State.State.NumValues = map[string]float64{"unknown": 1}
+
+	r, err := s.server.SetPortsState(nil, req)
+	s.Require().Nil(err)
+
+	s.Require().Equal(1, len(r.Items))
+	s.EqualValues(badRequest, r.Items[0].Base.Status)
+	s.Nil(port.GetState())
+}
+
+func (s *GTEServerTestSuite) getValidPortUpdateRequest() *pb.PortUpdateRequest {
+	nodeIds := s.getNodeIdentifiers(1)
+	return &pb.PortUpdateRequest{
+		Items: []*pb.PortUpdateRequest_UnitRequest{
+			{
+				Identifier: &pb.PortIdentifier{
+					NodeIdentifier: nodeIds.Ids[0],
+					PortTag:        "port",
+				},
+				State: &pb.PortState{
+					Tag: "port",
+					State: &pb.State{
+						NumValues: map[string]float64{"tStag": 300},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *GTEServerTestSuite) getValidPortStateRequest() *pb.PortStateRequest {
+	nodeIds := s.getNodeIdentifiers(1)
+	return &pb.PortStateRequest{
+		Items: []*pb.PortStateRequest_UnitRequest{
+			{
+				Identifier: &pb.PortIdentifier{
+					NodeIdentifier: nodeIds.Ids[0],
+					PortTag:        "port",
+				},
+			},
+		},
+	}
+}
+
+func (s *GTEServerTestSuite) TestGetDescription_Success() {
+	s.factory.ExpectNodeTypes("test")
+	s.factory.ExpectResponse(
+		mocks.NodeAdapterMock{
+			GetDecs: func() *pb.NodeDescription {
+				return &pb.NodeDescription{NodeType: "test"}
+			},
+		}, nil,
+	)
+
+	r, err := s.server.GetDescription(nil, &pb.Empty{})
+	s.Require().Nil(err)
+
+	s.Require().Equal(1, len(r.Nodes))
+	s.Equal("test", r.Nodes[0].NodeType)
+}
+
+func (s *GTEServerTestSuite) TestGetDescription_AdapterNotFound() {
+	s.factory.ExpectNodeTypes("test")
+	s.factory.ExpectResponse(nil, fmt.Errorf("not found"))
+
+	_, err := s.server.GetDescription(nil, &pb.Empty{})
+	s.Require().Error(err)
+}
+
 func (s *GTEServerTestSuite) getValidGetStateRequest() *pb.NodeStateRequest {
 	ids := s.getNodeIdentifiers(1)
 	result := &pb.NodeStateRequest{
@@ -575,6 +1869,85 @@ func (s *GTEServerTestSuite) getNodeIdentifiers(ids ...int32) *pb.NodeIdentifier
 	return result
 }
 
+func (s *GTEServerTestSuite) TestMetrics() {
+	registry := metrics.NewRegistry()
+	server := NewConfiguredGTEServer(context.Background(), GTEServerConfig{Metrics: registry})
+
+	req, _ := GetCreateRequest(
+		[]string{"outlet", "loss"},
+		[]string{adapters.OutletNodeType, adapters.PressureLossNodeType},
+		[]map[string]float64{{}, {}},
+	)
+	_, err := server.CreateNodes(nil, req)
+	s.Require().Nil(err)
+
+	buf := &bytes.Buffer{}
+	registry.Write(buf)
+	s.Contains(buf.String(), fmt.Sprintf("turbonetwork_nodes{node_type=\"%s\"} 1", adapters.OutletNodeType))
+	// pressure loss node requires sigma
+	s.Contains(buf.String(), fmt.Sprintf(
+		"turbonetwork_node_adapter_errors_total{node_type=\"%s\"} 1", adapters.PressureLossNodeType,
+	))
+}
+
+func (s *GTEServerTestSuite) TestMetrics_StoredNodeType() {
+	registry := metrics.NewRegistry()
+	s.server.adapterErrors = registry.NewCounterVec("adapter_errors", "", "node_type")
+	s.storage.ExpectGetResponse(adapters.NewTypedNode(graph.NewTestNode(0, 0, true, nil), "stored"), nil)
+	s.factory.ExpectResponse(mocks.NodeAdapterMock{
+		GetStateFunc: func(node graph.Node, requiredFields []string) (*pb.NodeState, error) {
+			return nil, fmt.Errorf("state failed")
+		},
+	}, nil)
+
+	_, err := s.server.GetNodesState(nil, &pb.NodeStateRequest{
+		Items: []*pb.NodeStateRequest_UnitRequest{{Identifier: &pb.NodeIdentifier{Id: 1, NodeType: "client"}}},
+	})
+	s.Require().Nil(err)
+
+	// label is taken from the stored node, so clients can not add labels
+	buf := &bytes.Buffer{}
+	registry.Write(buf)
+	s.Contains(buf.String(), "adapter_errors{node_type=\"stored\"} 1")
+	s.NotContains(buf.String(), "client")
+}
+
+func (s *GTEServerTestSuite) TestPersist_LogsRequestID() {
+	buf := &bytes.Buffer{}
+	s.server.logger = log.New(buf, "", 0)
+
+	// snapshot can not be saved to the removed directory
+	dir, err := ioutil.TempDir("", "node_storage")
+	s.Require().Nil(err)
+	s.Require().Nil(os.RemoveAll(dir))
+	s.server.nodeStorage, err = NewFileNodeStorage(filepath.Join(dir, "snapshot.json"), nil)
+	s.Require().Nil(err)
+
+	var requestID string
+	resp, err := s.callIntercepted(&pb.NodeIdentifiers{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		requestID = interceptors.GetRequestID(ctx)
+		return s.server.DeleteNodes(ctx, req.(*pb.NodeIdentifiers))
+	})
+	s.Require().Nil(err)
+	s.Require().Equal(1, len(resp.(*pb.NodeModifyResponse).Base.Messages))
+	s.Contains(buf.String(), fmt.Sprintf("request_id=%s failed to save snapshot", requestID))
+}
+
+// callIntercepted calls handler through the unary interceptor like gRPC server does
+func (s *GTEServerTestSuite) callIntercepted(req interface{}, handler grpc.UnaryHandler) (interface{}, error) {
+	interceptor := interceptors.NewUnaryServerInterceptor(interceptors.Config{Logger: log.New(ioutil.Discard, "", 0)})
+	return interceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/test"}, handler)
+}
+
 func TestGTEServerTestSuite(t *testing.T) {
 	suite.Run(t, new(GTEServerTestSuite))
 }
+
+// panicPortsNode imitates adapter bug which panics when ports of the node are accessed
+type panicPortsNode struct {
+	*graph.TestNode
+}
+
+func (n panicPortsNode) GetPorts() []graph.Port {
+	panic("ports are broken")
+}
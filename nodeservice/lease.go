@@ -0,0 +1,90 @@
+package nodeservice
+
+import (
+	"sync"
+	"time"
+)
+
+// default parameters of session leases
+const (
+	DefaultLeaseDuration  = time.Minute
+	DefaultReaperInterval = 10 * time.Second
+)
+
+// LeaseConfig configures deletion of the sessions which are not renewed by their clients
+type LeaseConfig struct {
+	// Duration is a time session lives after its last renewal
+	Duration time.Duration
+	// ReaperInterval is a period of checks for expired sessions
+	ReaperInterval time.Duration
+}
+
+func (c LeaseConfig) withDefaults() LeaseConfig {
+	if c.Duration <= 0 {
+		c.Duration = DefaultLeaseDuration
+	}
+	if c.ReaperInterval <= 0 {
+		c.ReaperInterval = DefaultReaperInterval
+	}
+	return c
+}
+
+func newLeaseTracker(duration time.Duration) *leaseTracker {
+	return &leaseTracker{
+		lock:      sync.Mutex{},
+		duration:  duration,
+		deadlines: make(map[string]time.Time),
+		now:       time.Now,
+	}
+}
+
+// leaseTracker keeps deadlines of the session leases
+type leaseTracker struct {
+	lock      sync.Mutex
+	duration  time.Duration
+	deadlines map[string]time.Time
+	now       func() time.Time
+}
+
+func (t *leaseTracker) renew(session string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.deadlines[session] = t.now().Add(t.duration)
+}
+
+// renewActive renews lease of the session if it has not expired yet. Expired lease is not renewed
+// because the reaper may have already taken its session for deletion
+func (t *leaseTracker) renewActive(session string) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	deadline, ok := t.deadlines[session]
+	if !ok || now.After(deadline) {
+		return false
+	}
+	t.deadlines[session] = now.Add(t.duration)
+	return true
+}
+
+func (t *leaseTracker) drop(session string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.deadlines, session)
+}
+
+// popExpired removes expired leases and returns their sessions
+func (t *leaseTracker) popExpired() []string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	var result []string
+	for session, deadline := range t.deadlines {
+		if now.After(deadline) {
+			result = append(result, session)
+			delete(t.deadlines, session)
+		}
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package nodeservice
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLeaseTracker(t *testing.T) {
+	now := time.Unix(0, 0)
+	tracker := newLeaseTracker(time.Minute)
+	tracker.now = func() time.Time {
+		return now
+	}
+
+	tracker.renew("a")
+	tracker.renew("b")
+	tracker.renew("c")
+	tracker.drop("c")
+
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, 0, len(tracker.popExpired()))
+	tracker.renew("b")
+
+	now = now.Add(45 * time.Second)
+	assert.Equal(t, []string{"a"}, tracker.popExpired())
+	assert.Equal(t, 0, len(tracker.popExpired()))
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, []string{"b"}, tracker.popExpired())
+}
+
+func TestLeaseTracker_RenewActive(t *testing.T) {
+	now := time.Unix(0, 0)
+	tracker := newLeaseTracker(time.Minute)
+	tracker.now = func() time.Time {
+		return now
+	}
+
+	assert.False(t, tracker.renewActive("a"))
+	tracker.renew("a")
+
+	now = now.Add(45 * time.Second)
+	assert.True(t, tracker.renewActive("a"))
+	now = now.Add(45 * time.Second)
+	assert.Equal(t, 0, len(tracker.popExpired()))
+
+	now = now.Add(time.Minute)
+	assert.False(t, tracker.renewActive("a"))
+	assert.Equal(t, []string{"a"}, tracker.popExpired())
+}
+
+func TestLeaseConfig_Defaults(t *testing.T) {
+	config := LeaseConfig{Duration: time.Second}.withDefaults()
+	assert.Equal(t, time.Second, config.Duration)
+	assert.Equal(t, DefaultReaperInterval, config.ReaperInterval)
+}
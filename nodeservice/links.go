@@ -0,0 +1,90 @@
+package nodeservice
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbonetwork/nodeservice/adapters"
+	"github.com/Sovianum/turbonetwork/pb"
+)
+
+// portRef is a port together with the node it belongs to
+type portRef struct {
+	node *adapters.TypedNode
+	tag  string
+	port graph.Port
+}
+
+// linkPorts links ports according to linkType. Weak ports are created through their nodes,
+// so that snapshots can save the link type
+func linkPorts(ref1, ref2 portRef, linkType pb.LinkType) {
+	port1, port2 := ref1.port, ref2.port
+	if linkType == pb.LinkType_WEAK_FIRST || linkType == pb.LinkType_WEAK_BOTH {
+		port1 = ref1.node.NewWeakPort(ref1.tag, ref1.port)
+	}
+	if linkType == pb.LinkType_WEAK_SECOND || linkType == pb.LinkType_WEAK_BOTH {
+		port2 = ref2.node.NewWeakPort(ref2.tag, ref2.port)
+	}
+	graph.Link(port1, port2)
+}
+
+// getLinkType returns type of the link with weak ends
+func getLinkType(weak1, weak2 bool) pb.LinkType {
+	switch {
+	case weak1 && weak2:
+		return pb.LinkType_WEAK_BOTH
+	case weak1:
+		return pb.LinkType_WEAK_FIRST
+	case weak2:
+		return pb.LinkType_WEAK_SECOND
+	default:
+		return pb.LinkType_SIMPLE
+	}
+}
+
+// isReferredBy checks that peer is linked to the port of ref either directly
+// or through the weak wrapper of the port
+func (r portRef) isReferredBy(peer graph.Port) bool {
+	link := peer.GetLinkPort()
+	if link == nil {
+		return false
+	}
+	return link == r.port || link == r.node.WeakPorts[r.tag]
+}
+
+// unlinkPorts detaches two ports linked to each other. Weak wrappers of the ports are dropped
+func unlinkPorts(ref1, ref2 portRef) error {
+	if !ref1.isReferredBy(ref2.port) || !ref2.isReferredBy(ref1.port) {
+		return fmt.Errorf("ports are not linked to each other")
+	}
+	ref1.port.SetLinkPort(nil)
+	ref2.port.SetLinkPort(nil)
+	delete(ref1.node.WeakPorts, ref1.tag)
+	delete(ref2.node.WeakPorts, ref2.tag)
+	return nil
+}
+
+// unlinkNode detaches all the ports of the node from the ports they are linked to,
+// so that linked ports keep references neither to the ports nor to their weak wrappers
+func unlinkNode(node *adapters.TypedNode) {
+	ports := node.Node.GetPorts()
+	own := make(map[graph.Port]bool, len(ports)+len(node.WeakPorts))
+	for _, port := range ports {
+		own[port] = true
+	}
+	for _, weak := range node.WeakPorts {
+		own[weak] = true
+	}
+
+	for _, port := range ports {
+		link := port.GetLinkPort()
+		if link == nil {
+			continue
+		}
+		// link port may already be attached to another port
+		if own[link.GetLinkPort()] {
+			link.SetLinkPort(nil)
+		}
+		port.SetLinkPort(nil)
+	}
+	node.WeakPorts = nil
+}
@@ -18,6 +18,7 @@ type NodeAdapterFactoryMock struct {
 	cnt         int
 	errList     []error
 	adapterList []adapters.NodeAdapter
+	nodeTypes   []string
 }
 
 // GetAdapter returns adapters in order of expectations
@@ -37,3 +38,14 @@ func (m *NodeAdapterFactoryMock) ExpectResponse(a adapters.NodeAdapter, err erro
 
 	return m
 }
+
+// GetNodeTypes returns node types saved by ExpectNodeTypes
+func (m *NodeAdapterFactoryMock) GetNodeTypes() []string {
+	return m.nodeTypes
+}
+
+// ExpectNodeTypes saves node types which will be returned by GetNodeTypes
+func (m *NodeAdapterFactoryMock) ExpectNodeTypes(nodeTypes ...string) *NodeAdapterFactoryMock {
+	m.nodeTypes = nodeTypes
+	return m
+}
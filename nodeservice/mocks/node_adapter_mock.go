@@ -7,7 +7,7 @@ import (
 
 // NodeAdapterMock mocks NodeAdapter interface
 type NodeAdapterMock struct {
-	CreateFunc   func(data *pb.RequestData) (graph.Node, error)
+	CreateFunc   func(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error)
 	UpdateFunc   func(node graph.Node, data *pb.RequestData) error
 	GetStateFunc func(node graph.Node, requiredFields []string) (*pb.NodeState, error)
 	GetPortFunc  func(tag string, node graph.Node) (graph.Port, error)
@@ -15,8 +15,8 @@ type NodeAdapterMock struct {
 }
 
 // Create mocks NodeAdapter.Create method
-func (m NodeAdapterMock) Create(data *pb.RequestData) (graph.Node, error) {
-	return m.CreateFunc(data)
+func (m NodeAdapterMock) Create(data *pb.RequestData, multiPorts map[string]int32) (graph.Node, error) {
+	return m.CreateFunc(data, multiPorts)
 }
 
 // Update mocks NodeAdapter.Update method
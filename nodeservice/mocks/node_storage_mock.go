@@ -23,13 +23,18 @@ func NewNodeStorageMock() *NodeStorageMock {
 
 // NodeStorageMock mocks NodeStorage interface
 type NodeStorageMock struct {
-	addCnt  int
-	getCnt  int
-	dropCnt int
+	addCnt        int
+	getCnt        int
+	dropCnt       int
+	sessionIDsCnt int
+	findCnt       int
 
-	addResponses  []Pair
-	getResponses  []Pair
-	dropResponses []error
+	addResponses        []Pair
+	getResponses        []Pair
+	dropResponses       []error
+	sessionIDsResponses [][]*pb.NodeIdentifier
+	rangeItems          []Pair
+	findResponses       []Pair
 }
 
 // ExpectAddResponse saves Add expectation
@@ -50,8 +55,26 @@ func (m *NodeStorageMock) ExpectDropResponse(err error) *NodeStorageMock {
 	return m
 }
 
+// ExpectFindByNameResponse saves FindByName expectation
+func (m *NodeStorageMock) ExpectFindByNameResponse(id *pb.NodeIdentifier, err error) *NodeStorageMock {
+	m.findResponses = append(m.findResponses, Pair{id, err})
+	return m
+}
+
+// ExpectSessionIDsResponse saves GetSessionIDs expectation
+func (m *NodeStorageMock) ExpectSessionIDsResponse(ids []*pb.NodeIdentifier) *NodeStorageMock {
+	m.sessionIDsResponses = append(m.sessionIDsResponses, ids)
+	return m
+}
+
+// ExpectRangeItem saves node which will be passed to the Range callback
+func (m *NodeStorageMock) ExpectRangeItem(id *pb.NodeIdentifier, node *adapters.TypedNode) *NodeStorageMock {
+	m.rangeItems = append(m.rangeItems, Pair{id, node})
+	return m
+}
+
 // Add mocks NodeStorage.Add method
-func (m *NodeStorageMock) Add(node *adapters.TypedNode) (*pb.NodeIdentifier, error) {
+func (m *NodeStorageMock) Add(session string, node *adapters.TypedNode) (*pb.NodeIdentifier, error) {
 	if m.addCnt >= len(m.addResponses) {
 		return nil, fmt.Errorf("unexpected add request")
 	}
@@ -87,5 +110,42 @@ func (m *NodeStorageMock) Drop(id *pb.NodeIdentifier) error {
 	if m.dropCnt >= len(m.dropResponses) {
 		return fmt.Errorf("unexpected drop request")
 	}
-	return m.dropResponses[m.addCnt]
+	r := m.dropResponses[m.dropCnt]
+	m.dropCnt++
+	return r
+}
+
+// FindByName mocks NodeStorage.FindByName method
+func (m *NodeStorageMock) FindByName(session, name string) (*pb.NodeIdentifier, error) {
+	if m.findCnt >= len(m.findResponses) {
+		return nil, fmt.Errorf("unexpected find by name request")
+	}
+	r := m.findResponses[m.findCnt]
+	m.findCnt++
+
+	var err error
+	if r.Second != nil {
+		err = r.Second.(error)
+	}
+
+	return r.First.(*pb.NodeIdentifier), err
+}
+
+// GetSessionIDs mocks NodeStorage.GetSessionIDs method
+func (m *NodeStorageMock) GetSessionIDs(session string) []*pb.NodeIdentifier {
+	if m.sessionIDsCnt >= len(m.sessionIDsResponses) {
+		return nil
+	}
+	r := m.sessionIDsResponses[m.sessionIDsCnt]
+	m.sessionIDsCnt++
+	return r
+}
+
+// Range mocks NodeStorage.Range method
+func (m *NodeStorageMock) Range(f func(id *pb.NodeIdentifier, node *adapters.TypedNode) bool) {
+	for _, item := range m.rangeItems {
+		if !f(item.First.(*pb.NodeIdentifier), item.Second.(*adapters.TypedNode)) {
+			return
+		}
+	}
 }
@@ -0,0 +1,37 @@
+package mocks
+
+import (
+	"github.com/Sovianum/turbonetwork/pb"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+// NewProcessStreamMock constructs ProcessStreamMock which uses ctx as a stream context
+func NewProcessStreamMock(ctx context.Context) *ProcessStreamMock {
+	return &ProcessStreamMock{ctx: ctx}
+}
+
+// ProcessStreamMock mocks NodeService_ProcessStreamServer interface and collects sent items
+type ProcessStreamMock struct {
+	grpc.ServerStream
+	ctx context.Context
+
+	// Items contains all the sent items
+	Items []*pb.NodeModifyResponse_UnitResponse
+	// OnSend is called after every sent item if it is set
+	OnSend func(item *pb.NodeModifyResponse_UnitResponse)
+}
+
+// Context returns context the mock was constructed with
+func (m *ProcessStreamMock) Context() context.Context {
+	return m.ctx
+}
+
+// Send saves item
+func (m *ProcessStreamMock) Send(item *pb.NodeModifyResponse_UnitResponse) error {
+	m.Items = append(m.Items, item)
+	if m.OnSend != nil {
+		m.OnSend(item)
+	}
+	return nil
+}
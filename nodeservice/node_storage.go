@@ -4,41 +4,57 @@ import (
 	"github.com/Sovianum/turbonetwork/common"
 	"github.com/Sovianum/turbonetwork/nodeservice/adapters"
 	"github.com/Sovianum/turbonetwork/pb"
+	"sort"
 	"sync"
 )
 
 // NodeStorage is a wrapper around ObjectStorage which also
-// automatically generates unique ids and casts TypedNode objects to and from interface{}
+// automatically generates unique ids and casts TypedNode objects to and from interface{}.
+// Nodes are grouped into sessions: identifier of the node contains its session,
+// so node can not be accessed by identifier of another session.
+// Non empty node names are unique inside the session
 type NodeStorage interface {
-	Add(node *adapters.TypedNode) (*pb.NodeIdentifier, error)
+	// Add returns error with conflict code if session already contains node with the same name
+	Add(session string, node *adapters.TypedNode) (*pb.NodeIdentifier, error)
 	Get(id *pb.NodeIdentifier) (*adapters.TypedNode, error)
 	Drop(id *pb.NodeIdentifier) error
+	// FindByName returns identifier of the node with name from the session
+	FindByName(session, name string) (*pb.NodeIdentifier, error)
+	// GetSessionIDs returns identifiers of all the nodes of the session sorted by id
+	GetSessionIDs(session string) []*pb.NodeIdentifier
+	// Range calls f for all the stored nodes in arbitrary order until f returns false
+	Range(f func(id *pb.NodeIdentifier, node *adapters.TypedNode) bool)
 }
 
 // NewMapNodeStorage creates NodeStorage based on map based ObjectStorage
 func NewMapNodeStorage() NodeStorage {
 	return &mapNodeStorage{
-		idCnt:         1,
-		idLock:        sync.Mutex{},
+		sessionLock:   sync.Mutex{},
+		idCnts:        make(map[string]int32),
+		sessions:      make(map[string]map[pb.NodeIdentifier]bool),
+		names:         make(map[string]map[string]pb.NodeIdentifier),
 		objectStorage: common.NewMapObjectStorage(),
 	}
 }
 
 type mapNodeStorage struct {
 	objectStorage common.ObjectStorage
-	idLock        sync.Mutex
-	idCnt         int32
+
+	sessionLock sync.Mutex
+	idCnts      map[string]int32
+	sessions    map[string]map[pb.NodeIdentifier]bool
+	names       map[string]map[string]pb.NodeIdentifier
 }
 
-func (s *mapNodeStorage) Add(node *adapters.TypedNode) (*pb.NodeIdentifier, error) {
-	s.idLock.Lock()
-	id := pb.NodeIdentifier{Id: s.idCnt, NodeType: node.NodeType}
-	s.idCnt++
-	s.idLock.Unlock()
+func (s *mapNodeStorage) Add(session string, node *adapters.TypedNode) (*pb.NodeIdentifier, error) {
+	s.sessionLock.Lock()
+	defer s.sessionLock.Unlock()
 
-	if err := s.objectStorage.Add(id, node); err != nil {
+	id := pb.NodeIdentifier{Id: s.idCnts[session] + 1, NodeType: node.NodeType, Session: session}
+	if err := s.add(id, node); err != nil {
 		return nil, err
 	}
+	s.idCnts[session]++
 
 	return &id, nil
 }
@@ -48,12 +64,151 @@ func (s *mapNodeStorage) Get(id *pb.NodeIdentifier) (*adapters.TypedNode, error)
 		val interface{}
 		err error
 	)
-	if val, err = s.objectStorage.Get(*id); err != nil {
+	if val, err = s.objectStorage.Get(getStorageKey(id)); err != nil {
 		return nil, err
 	}
 	return val.(*adapters.TypedNode), nil
 }
 
 func (s *mapNodeStorage) Drop(id *pb.NodeIdentifier) error {
-	return s.objectStorage.Drop(id)
+	s.sessionLock.Lock()
+	defer s.sessionLock.Unlock()
+
+	key := getStorageKey(id)
+	// name of the node is necessary to clean name index
+	val, getErr := s.objectStorage.Get(key)
+	if err := s.objectStorage.Drop(key); err != nil {
+		return err
+	}
+
+	if getErr == nil {
+		if name := val.(*adapters.TypedNode).Node.GetInstanceName(); name != "" {
+			delete(s.names[id.Session], name)
+		}
+	}
+	if ids, ok := s.sessions[id.Session]; ok {
+		delete(ids, key)
+		// id counter is kept so that ids of dropped nodes are not reused
+		if len(ids) == 0 {
+			delete(s.sessions, id.Session)
+			delete(s.names, id.Session)
+		}
+	}
+	return nil
+}
+
+func (s *mapNodeStorage) FindByName(session, name string) (*pb.NodeIdentifier, error) {
+	s.sessionLock.Lock()
+	defer s.sessionLock.Unlock()
+
+	id, ok := s.names[session][name]
+	if !ok || name == "" {
+		return nil, common.NewError(notFound, "node with name \"%s\" not found in session \"%s\"", name, session)
+	}
+	return &id, nil
+}
+
+func (s *mapNodeStorage) GetSessionIDs(session string) []*pb.NodeIdentifier {
+	s.sessionLock.Lock()
+	defer s.sessionLock.Unlock()
+
+	result := make([]*pb.NodeIdentifier, 0, len(s.sessions[session]))
+	for id := range s.sessions[session] {
+		idCopy := id
+		result = append(result, &idCopy)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
+
+func (s *mapNodeStorage) Range(f func(id *pb.NodeIdentifier, node *adapters.TypedNode) bool) {
+	s.objectStorage.Range(func(key, value interface{}) bool {
+		id := key.(pb.NodeIdentifier)
+		return f(&id, value.(*adapters.TypedNode))
+	})
+}
+
+// getAllIDs returns identifiers of all the nodes sorted by session and id
+func (s *mapNodeStorage) getAllIDs() []*pb.NodeIdentifier {
+	s.sessionLock.Lock()
+	sessions := make([]string, 0, len(s.sessions))
+	for session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	s.sessionLock.Unlock()
+	sort.Strings(sessions)
+
+	var result []*pb.NodeIdentifier
+	for _, session := range sessions {
+		result = append(result, s.GetSessionIDs(session)...)
+	}
+	return result
+}
+
+// getIDCounters returns copy of id counters of all the sessions
+func (s *mapNodeStorage) getIDCounters() map[string]int32 {
+	s.sessionLock.Lock()
+	defer s.sessionLock.Unlock()
+
+	result := make(map[string]int32, len(s.idCnts))
+	for session, cnt := range s.idCnts {
+		result[session] = cnt
+	}
+	return result
+}
+
+// addWithID saves node under existing identifier. Id counter of the session is moved
+// forward if necessary, so that new nodes do not get the same identifier
+func (s *mapNodeStorage) addWithID(id pb.NodeIdentifier, node *adapters.TypedNode) error {
+	s.sessionLock.Lock()
+	defer s.sessionLock.Unlock()
+
+	id = getStorageKey(&id)
+	if err := s.add(id, node); err != nil {
+		return err
+	}
+
+	if s.idCnts[id.Session] < id.Id {
+		s.idCnts[id.Session] = id.Id
+	}
+	return nil
+}
+
+// add saves node and updates session indices. It must be called under sessionLock
+func (s *mapNodeStorage) add(id pb.NodeIdentifier, node *adapters.TypedNode) error {
+	name := node.Node.GetInstanceName()
+	if _, ok := s.names[id.Session][name]; ok && name != "" {
+		return common.NewError(conflict, "node with name \"%s\" already exists in session \"%s\"", name, id.Session)
+	}
+
+	if err := s.objectStorage.Add(id, node); err != nil {
+		return err
+	}
+
+	if _, ok := s.sessions[id.Session]; !ok {
+		s.sessions[id.Session] = make(map[pb.NodeIdentifier]bool)
+		s.names[id.Session] = make(map[string]pb.NodeIdentifier)
+	}
+	s.sessions[id.Session][id] = true
+	if name != "" {
+		s.names[id.Session][name] = id
+	}
+	return nil
+}
+
+// setIDCounter moves id counter of the session forward to cnt
+func (s *mapNodeStorage) setIDCounter(session string, cnt int32) {
+	s.sessionLock.Lock()
+	defer s.sessionLock.Unlock()
+
+	if s.idCnts[session] < cnt {
+		s.idCnts[session] = cnt
+	}
+}
+
+// getStorageKey returns identifier containing only fields which identify node in storage
+func getStorageKey(id *pb.NodeIdentifier) pb.NodeIdentifier {
+	return pb.NodeIdentifier{Id: id.Id, NodeType: id.NodeType, Session: id.Session}
 }
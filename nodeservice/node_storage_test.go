@@ -2,7 +2,9 @@ package nodeservice
 
 import (
 	"github.com/Sovianum/turbocycle/core/graph"
+	"github.com/Sovianum/turbonetwork/common"
 	"github.com/Sovianum/turbonetwork/nodeservice/adapters"
+	"github.com/Sovianum/turbonetwork/pb"
 	"github.com/stretchr/testify/suite"
 	"testing"
 )
@@ -17,7 +19,7 @@ func (s *NodeStorageTestSuite) SetupTest() {
 }
 
 func (s *NodeStorageTestSuite) TestAdd() {
-	id, err := s.storage.Add(adapters.NewTypedNode(
+	id, err := s.storage.Add("", adapters.NewTypedNode(
 		graph.NewTestNode(0, 0, true, nil),
 		"test",
 	))
@@ -28,7 +30,7 @@ func (s *NodeStorageTestSuite) TestAdd() {
 }
 
 func (s *NodeStorageTestSuite) TestDelete() {
-	id, err := s.storage.Add(adapters.NewTypedNode(
+	id, err := s.storage.Add("", adapters.NewTypedNode(
 		graph.NewTestNode(0, 0, true, nil),
 		"test",
 	))
@@ -36,12 +38,15 @@ func (s *NodeStorageTestSuite) TestDelete() {
 
 	err = s.storage.Drop(id)
 	s.Require().Nil(err)
+
+	_, err = s.storage.Get(id)
+	s.Error(err)
 }
 
 func (s *NodeStorageTestSuite) TestGet() {
 	inputNode := graph.NewTestNode(0, 0, true, nil)
 
-	id, err := s.storage.Add(adapters.NewTypedNode(inputNode, "test"))
+	id, err := s.storage.Add("", adapters.NewTypedNode(inputNode, "test"))
 
 	s.Require().Nil(err)
 
@@ -50,6 +55,68 @@ func (s *NodeStorageTestSuite) TestGet() {
 	s.Equal(node.Node, inputNode)
 }
 
+func (s *NodeStorageTestSuite) TestSessions() {
+	idA, err := s.storage.Add("a", adapters.NewTypedNode(graph.NewTestNode(0, 0, true, nil), "test"))
+	s.Require().Nil(err)
+	idB, err := s.storage.Add("b", adapters.NewTypedNode(graph.NewTestNode(0, 0, true, nil), "test"))
+	s.Require().Nil(err)
+
+	// ids are generated independently inside sessions
+	s.EqualValues(1, idA.Id)
+	s.EqualValues(1, idB.Id)
+	s.Equal("a", idA.Session)
+
+	_, err = s.storage.Get(&pb.NodeIdentifier{Id: idA.Id, NodeType: idA.NodeType, Session: "b"})
+	s.Require().Nil(err)
+	_, err = s.storage.Get(&pb.NodeIdentifier{Id: idA.Id, NodeType: idA.NodeType, Session: "c"})
+	s.Error(err)
+
+	s.Equal([]*pb.NodeIdentifier{idA}, s.storage.GetSessionIDs("a"))
+	s.Require().Nil(s.storage.Drop(idA))
+	s.Equal(0, len(s.storage.GetSessionIDs("a")))
+	s.Equal(1, len(s.storage.GetSessionIDs("b")))
+
+	// ids of dropped nodes are not reused
+	idA, err = s.storage.Add("a", adapters.NewTypedNode(graph.NewTestNode(0, 0, true, nil), "test"))
+	s.Require().Nil(err)
+	s.EqualValues(2, idA.Id)
+}
+
+func (s *NodeStorageTestSuite) TestNames() {
+	node := graph.NewTestNode(0, 0, true, nil)
+	node.SetName("node")
+	id, err := s.storage.Add("a", adapters.NewTypedNode(node, "test"))
+	s.Require().Nil(err)
+
+	// names are unique inside the session only
+	_, err = s.storage.Add("a", adapters.NewTypedNode(node, "test"))
+	s.Equal(conflict, common.GetCode(err, ok))
+	_, err = s.storage.Add("b", adapters.NewTypedNode(node, "test"))
+	s.Nil(err)
+
+	foundID, err := s.storage.FindByName("a", "node")
+	s.Require().Nil(err)
+	s.Equal(id, foundID)
+	_, err = s.storage.FindByName("c", "node")
+	s.Error(err)
+
+	// name is released when node is dropped
+	s.Require().Nil(s.storage.Drop(id))
+	_, err = s.storage.FindByName("a", "node")
+	s.Error(err)
+	_, err = s.storage.Add("a", adapters.NewTypedNode(node, "test"))
+	s.Nil(err)
+}
+
+func (s *NodeStorageTestSuite) TestNames_Empty() {
+	for i := 0; i != 2; i++ {
+		_, err := s.storage.Add("", adapters.NewTypedNode(graph.NewTestNode(0, 0, true, nil), "test"))
+		s.Require().Nil(err)
+	}
+	_, err := s.storage.FindByName("", "")
+	s.Error(err)
+}
+
 func TestNodeStorageTestSuite(t *testing.T) {
 	suite.Run(t, new(NodeStorageTestSuite))
 }
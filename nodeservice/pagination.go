@@ -0,0 +1,42 @@
+package nodeservice
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// page sizes of list requests
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// getPageSize returns page size limited by maxPageSize, defaultPageSize is used if size is not positive
+func getPageSize(size int32) int {
+	switch {
+	case size <= 0:
+		return defaultPageSize
+	case size > maxPageSize:
+		return maxPageSize
+	default:
+		return int(size)
+	}
+}
+
+// getPageToken returns token of the page following the node with id.
+// Ids of the session are never reused, so token stays valid when nodes are added or deleted
+func getPageToken(id int32) string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// parsePageToken returns id of the last node of the previous page, empty token corresponds to 0
+func parsePageToken(token string) (int32, error) {
+	if token == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(token, 10, 32)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid page token \"%s\"", token)
+	}
+	return int32(id), nil
+}
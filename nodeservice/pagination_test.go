@@ -0,0 +1,28 @@
+package nodeservice
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetPageSize(t *testing.T) {
+	assert.Equal(t, defaultPageSize, getPageSize(0))
+	assert.Equal(t, defaultPageSize, getPageSize(-1))
+	assert.Equal(t, 10, getPageSize(10))
+	assert.Equal(t, maxPageSize, getPageSize(maxPageSize+1))
+}
+
+func TestPageToken(t *testing.T) {
+	id, err := parsePageToken(getPageToken(42))
+	assert.Nil(t, err)
+	assert.EqualValues(t, 42, id)
+
+	id, err = parsePageToken("")
+	assert.Nil(t, err)
+	assert.EqualValues(t, 0, id)
+
+	_, err = parsePageToken("-1")
+	assert.Error(t, err)
+	_, err = parsePageToken("abc")
+	assert.Error(t, err)
+}
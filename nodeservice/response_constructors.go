@@ -1,6 +1,23 @@
 package nodeservice
 
-import "github.com/Sovianum/turbonetwork/pb"
+import (
+	"github.com/Sovianum/turbonetwork/common"
+	"github.com/Sovianum/turbonetwork/pb"
+	"time"
+)
+
+func getLeaseResponse(lease time.Duration) *pb.LeaseResponse {
+	return &pb.LeaseResponse{
+		Base:        getBaseSuccessResponseItem(),
+		LeaseMillis: int64(lease / time.Millisecond),
+	}
+}
+
+func getLeaseErrResponse(err *common.Error) *pb.LeaseResponse {
+	return &pb.LeaseResponse{
+		Base: getBaseErrResponseItem(err.Msg, err.Code),
+	}
+}
 
 func getStateSuccessResponse(items []*pb.NodeStateResponse_UnitResponse) *pb.NodeStateResponse {
 	return &pb.NodeStateResponse{
@@ -9,9 +26,9 @@ func getStateSuccessResponse(items []*pb.NodeStateResponse_UnitResponse) *pb.Nod
 	}
 }
 
-func getStateErrResponse(msg string, status int32) *pb.NodeStateResponse {
+func getStateErrResponse(err *common.Error) *pb.NodeStateResponse {
 	return &pb.NodeStateResponse{
-		Base: getBaseErrResponseItem(msg, status),
+		Base: getBaseErrResponseItem(err.Msg, err.Code),
 	}
 }
 
@@ -23,14 +40,99 @@ func getStateSuccessResponseItem(id *pb.NodeIdentifier, state *pb.NodeState) *pb
 	}
 }
 
-func getStateErrResponseItem(msg string, status int32) *pb.NodeStateResponse_UnitResponse {
+func getStateErrResponseItem(msg string, status pb.StatusCode) *pb.NodeStateResponse_UnitResponse {
 	return &pb.NodeStateResponse_UnitResponse{
 		Base: getBaseErrResponseItem(msg, status),
 	}
 }
 
-func getModifyErrResponse(msg string, status int32) *pb.NodeModifyResponse {
-	return &pb.NodeModifyResponse{
+func getListSuccessResponse(items []*pb.NodeListResponse_UnitResponse, nextPageToken string) *pb.NodeListResponse {
+	return &pb.NodeListResponse{
+		Base:          getBaseSuccessResponseItem(),
+		Items:         items,
+		NextPageToken: nextPageToken,
+	}
+}
+
+func getListErrResponse(err *common.Error) *pb.NodeListResponse {
+	return &pb.NodeListResponse{
+		Base: getBaseErrResponseItem(err.Msg, err.Code),
+	}
+}
+
+func getListResponseItem(id *pb.NodeIdentifier, name string) *pb.NodeListResponse_UnitResponse {
+	return &pb.NodeListResponse_UnitResponse{
+		Identifier: id,
+		Name:       name,
+	}
+}
+
+func getProcessGraphSuccessResponse(
+	callOrder []*pb.NodeIdentifier, iterations int, converged bool,
+) *pb.ProcessGraphResponse {
+	return &pb.ProcessGraphResponse{
+		Base:       getBaseSuccessResponseItem(),
+		CallOrder:  callOrder,
+		Iterations: int32(iterations),
+		Converged:  converged,
+	}
+}
+
+func getProcessGraphErrResponse(err *common.Error) *pb.ProcessGraphResponse {
+	return &pb.ProcessGraphResponse{
+		Base: getBaseErrResponseItem(err.Msg, err.Code),
+	}
+}
+
+func getPortStateSuccessResponse(items []*pb.PortStateResponse_UnitResponse) *pb.PortStateResponse {
+	return &pb.PortStateResponse{
+		Base:  getBaseSuccessResponseItem(),
+		Items: items,
+	}
+}
+
+func getPortStateErrResponse(err *common.Error) *pb.PortStateResponse {
+	return &pb.PortStateResponse{
+		Base: getBaseErrResponseItem(err.Msg, err.Code),
+	}
+}
+
+func getPortStateSuccessResponseItem(id *pb.PortIdentifier, state *pb.PortState) *pb.PortStateResponse_UnitResponse {
+	return &pb.PortStateResponse_UnitResponse{
+		Base:       getBaseSuccessResponseItem(),
+		Identifier: id,
+		State:      state,
+	}
+}
+
+func getPortStateErrResponseItem(msg string, status pb.StatusCode) *pb.PortStateResponse_UnitResponse {
+	return &pb.PortStateResponse_UnitResponse{
+		Base: getBaseErrResponseItem(msg, status),
+	}
+}
+
+func getPortModifySuccessResponse(items []*pb.PortModifyResponse_UnitResponse) *pb.PortModifyResponse {
+	return &pb.PortModifyResponse{
+		Base:  getBaseSuccessResponseItem(),
+		Items: items,
+	}
+}
+
+func getPortModifyErrResponse(err *common.Error) *pb.PortModifyResponse {
+	return &pb.PortModifyResponse{
+		Base: getBaseErrResponseItem(err.Msg, err.Code),
+	}
+}
+
+func getPortModifySuccessResponseItem(id *pb.PortIdentifier) *pb.PortModifyResponse_UnitResponse {
+	return &pb.PortModifyResponse_UnitResponse{
+		Identifier: id,
+		Base:       getBaseSuccessResponseItem(),
+	}
+}
+
+func getPortModifyErrResponseItem(msg string, status pb.StatusCode) *pb.PortModifyResponse_UnitResponse {
+	return &pb.PortModifyResponse_UnitResponse{
 		Base: getBaseErrResponseItem(msg, status),
 	}
 }
@@ -42,7 +144,28 @@ func getModifySuccessResponse(items []*pb.NodeModifyResponse_UnitResponse) *pb.N
 	}
 }
 
-func getModifyErrResponseItem(msg string, status int32) *pb.NodeModifyResponse_UnitResponse {
+func getModifyErrResponse(err *common.Error) *pb.NodeModifyResponse {
+	return &pb.NodeModifyResponse{
+		Base: getBaseErrResponseItem(err.Msg, err.Code),
+	}
+}
+
+func getModifyRolledBackResponse(items []*pb.NodeModifyResponse_UnitResponse, msg string, status pb.StatusCode) *pb.NodeModifyResponse {
+	return &pb.NodeModifyResponse{
+		Base:       getBaseErrResponseItem(msg, status),
+		Items:      items,
+		RolledBack: true,
+	}
+}
+
+func getModifyRollbackFailedResponse(items []*pb.NodeModifyResponse_UnitResponse, msg string) *pb.NodeModifyResponse {
+	return &pb.NodeModifyResponse{
+		Base:  getBaseErrResponseItem(msg, internalError),
+		Items: items,
+	}
+}
+
+func getModifyErrResponseItem(msg string, status pb.StatusCode) *pb.NodeModifyResponse_UnitResponse {
 	return &pb.NodeModifyResponse_UnitResponse{
 		Base: getBaseErrResponseItem(msg, status),
 	}
@@ -55,7 +178,7 @@ func getModifySuccessResponseItem(ids ...*pb.NodeIdentifier) *pb.NodeModifyRespo
 	}
 }
 
-func getBaseErrResponseItem(msg string, status int32) *pb.BaseResponse {
+func getBaseErrResponseItem(msg string, status pb.StatusCode) *pb.BaseResponse {
 	return &pb.BaseResponse{
 		Status:      status,
 		Description: msg,
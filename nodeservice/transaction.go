@@ -0,0 +1,132 @@
+package nodeservice
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbonetwork/pb"
+)
+
+const (
+	rolledBackMsg     = "rolled back: batch contains failed items"
+	rollbackFailedMsg = "not rolled back: batch contains failed items and rollback failed"
+)
+
+// rollbackLog collects actions which revert successfully applied items of a batch request
+type rollbackLog struct {
+	actions []func() error
+}
+
+func (l *rollbackLog) add(action func() error) {
+	l.actions = append(l.actions, action)
+}
+
+// rollback runs saved actions in reverse order and returns all the errors occurred
+func (l *rollbackLog) rollback() []error {
+	var errList []error
+	for i := len(l.actions) - 1; i >= 0; i-- {
+		if err := l.actions[i](); err != nil {
+			errList = append(errList, err)
+		}
+	}
+	l.actions = nil
+	return errList
+}
+
+// finishModify builds response of the modifying batch request. If request is atomic and
+// some of its items failed, all the applied items are reverted and marked as rolled back.
+// If some of the items can not be reverted, response is not marked as rolled back
+func finishModify(atomic bool, items []*pb.NodeModifyResponse_UnitResponse, log *rollbackLog) *pb.NodeModifyResponse {
+	if !atomic || !hasFailedItems(items) {
+		return getModifySuccessResponse(items)
+	}
+
+	errList := log.rollback()
+	itemMsg := rolledBackMsg
+	if errList != nil {
+		itemMsg = rollbackFailedMsg
+	}
+	for _, item := range items {
+		if item.Base.Status == ok {
+			item.Base = getBaseErrResponseItem(itemMsg, failedDependency)
+		}
+	}
+
+	if errList != nil {
+		return getModifyRollbackFailedResponse(items, fmt.Sprintf("rollback failed: %s", joinErrors(errList)))
+	}
+	return getModifyRolledBackResponse(items, "batch rolled back", ok)
+}
+
+func hasFailedItems(items []*pb.NodeModifyResponse_UnitResponse) bool {
+	for _, item := range items {
+		if item.Base.Status != ok {
+			return true
+		}
+	}
+	return false
+}
+
+// getRollbackData builds request data which returns parameters changed by the update
+// to the values they had before it. Values are taken from the state captured before the update.
+// Parameters missing in the state take values from the data applied to the node
+// (see TypedNode.GetAppliedData), which may be outdated (e.g. eta after compressor map was applied),
+// so it is not preferred over the state. If update contains parameters which are known neither
+// to the state nor to the applied data, they may affect other parameters, so all the applied
+// numeric parameters are restored too. Derived values of the state are restored only if they
+// are changed by the update, because adapters may reject them together with their sources
+func getRollbackData(update, applied *pb.RequestData, state *pb.State) *pb.RequestData {
+	result := &pb.RequestData{
+		DKwargs: make(map[string]float64),
+		SKwargs: make(map[string]string),
+	}
+
+	restoreApplied := false
+	for key := range update.GetDKwargs() {
+		if val, ok := getPrevNumValue(key, applied, state); ok {
+			result.DKwargs[key] = val
+		} else {
+			restoreApplied = true
+		}
+	}
+	for key := range update.GetSKwargs() {
+		if val, ok := getPrevStringValue(key, applied, state); ok {
+			result.SKwargs[key] = val
+		} else {
+			restoreApplied = true
+		}
+	}
+
+	if restoreApplied {
+		for key := range applied.GetDKwargs() {
+			if _, ok := result.DKwargs[key]; !ok {
+				result.DKwargs[key], _ = getPrevNumValue(key, applied, state)
+			}
+		}
+	}
+	return result
+}
+
+// getPrevNumValue returns value of the parameter before the update, state is preferred over applied data
+func getPrevNumValue(key string, applied *pb.RequestData, state *pb.State) (float64, bool) {
+	if val, ok := state.GetNumValues()[key]; ok {
+		return val, true
+	}
+	val, ok := applied.GetDKwargs()[key]
+	return val, ok
+}
+
+func getPrevStringValue(key string, applied *pb.RequestData, state *pb.State) (string, bool) {
+	if val, ok := state.GetStringValues()[key]; ok {
+		return val, true
+	}
+	val, ok := applied.GetSKwargs()[key]
+	return val, ok
+}
+
+func joinErrors(errList []error) string {
+	result := "["
+	for _, err := range errList {
+		result += err.Error() + "; "
+	}
+	result += "]"
+	return result
+}
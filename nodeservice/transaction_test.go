@@ -0,0 +1,69 @@
+package nodeservice
+
+import (
+	"fmt"
+	"github.com/Sovianum/turbonetwork/pb"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRollbackLog_Order(t *testing.T) {
+	var order []int
+	log := &rollbackLog{}
+	for i := 0; i != 3; i++ {
+		i := i
+		log.add(func() error {
+			order = append(order, i)
+			return nil
+		})
+	}
+
+	assert.Nil(t, log.rollback())
+	assert.Equal(t, []int{2, 1, 0}, order)
+}
+
+func TestFinishModify_RollbackFailed(t *testing.T) {
+	log := &rollbackLog{}
+	log.add(func() error {
+		return fmt.Errorf("err rollback")
+	})
+
+	items := []*pb.NodeModifyResponse_UnitResponse{
+		getModifySuccessResponseItem(&pb.NodeIdentifier{Id: 1}),
+		getModifyErrResponseItem("err", notFound),
+	}
+	response := finishModify(true, items, log)
+
+	assert.False(t, response.RolledBack)
+	assert.EqualValues(t, internalError, response.Base.Status)
+	assert.EqualValues(t, failedDependency, response.Items[0].Base.Status)
+	assert.Equal(t, rollbackFailedMsg, response.Items[0].Base.Description)
+}
+
+func TestGetRollbackData(t *testing.T) {
+	applied := &pb.RequestData{
+		DKwargs: map[string]float64{"a": 1, "c": 3, "e": 5},
+		SKwargs: map[string]string{"s": "val", "t": "applied"},
+	}
+	state := &pb.State{
+		NumValues:    map[string]float64{"a": 10, "b": 2, "c": 3, "derived": 4},
+		StringValues: map[string]string{"s": "val"},
+	}
+
+	data := getRollbackData(&pb.RequestData{
+		DKwargs: map[string]float64{"a": 100, "b": 20, "e": 50},
+		SKwargs: map[string]string{"s": "newVal", "t": "newVal"},
+	}, applied, state)
+	// applied value of a is outdated, so it is taken from the state like b which was never passed.
+	// Applied data is used for parameters the state does not report
+	assert.Equal(t, map[string]float64{"a": 10, "b": 2, "e": 5}, data.DKwargs)
+	assert.Equal(t, map[string]string{"s": "val", "t": "applied"}, data.SKwargs)
+
+	data = getRollbackData(&pb.RequestData{
+		DKwargs: map[string]float64{"a": 100},
+		SKwargs: map[string]string{"unknown": "val"},
+	}, applied, state)
+	// all the applied parameters are restored, derived values of the state are not
+	assert.Equal(t, map[string]float64{"a": 10, "c": 3, "e": 5}, data.DKwargs)
+	assert.Equal(t, 0, len(data.SKwargs))
+}
@@ -10,7 +10,12 @@ It is generated from these files:
 
 It has these top-level messages:
 	Empty
+	SessionIdentifier
+	LeaseResponse
+	ProcessGraphRequest
+	ProcessGraphResponse
 	PortStateResponse
+	NodeListResponse
 	NodeStateResponse
 	PortModifyResponse
 	NodeModifyResponse
@@ -25,6 +30,7 @@ It has these top-level messages:
 	NodeUpdateRequest
 	PortUpdateRequest
 	PortStateRequest
+	NodeListRequest
 	NodeStateRequest
 	NodeCreateRequest
 	PortIdentifier
@@ -66,6 +72,52 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+// StatusCode is a status of the request or its item. Values are analogs of HTTP statuses
+type StatusCode int32
+
+const (
+	StatusCode_UNSET       StatusCode = 0
+	StatusCode_OK          StatusCode = 200
+	StatusCode_BAD_REQUEST StatusCode = 400
+	StatusCode_NOT_FOUND   StatusCode = 404
+	StatusCode_CONFLICT    StatusCode = 409
+	// item is reverted by atomic request
+	StatusCode_FAILED_DEPENDENCY StatusCode = 424
+	// request context is cancelled before all the items are handled
+	StatusCode_CANCELLED      StatusCode = 499
+	StatusCode_INTERNAL_ERROR StatusCode = 500
+	// request deadline is exceeded before all the items are handled
+	StatusCode_DEADLINE_EXCEEDED StatusCode = 504
+)
+
+var StatusCode_name = map[int32]string{
+	0:   "UNSET",
+	200: "OK",
+	400: "BAD_REQUEST",
+	404: "NOT_FOUND",
+	409: "CONFLICT",
+	424: "FAILED_DEPENDENCY",
+	499: "CANCELLED",
+	500: "INTERNAL_ERROR",
+	504: "DEADLINE_EXCEEDED",
+}
+var StatusCode_value = map[string]int32{
+	"UNSET":             0,
+	"OK":                200,
+	"BAD_REQUEST":       400,
+	"NOT_FOUND":         404,
+	"CONFLICT":          409,
+	"FAILED_DEPENDENCY": 424,
+	"CANCELLED":         499,
+	"INTERNAL_ERROR":    500,
+	"DEADLINE_EXCEEDED": 504,
+}
+
+func (x StatusCode) String() string {
+	return proto.EnumName(StatusCode_name, int32(x))
+}
+func (StatusCode) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
 type LinkType int32
 
 const (
@@ -91,7 +143,7 @@ var LinkType_value = map[string]int32{
 func (x LinkType) String() string {
 	return proto.EnumName(LinkType_name, int32(x))
 }
-func (LinkType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+func (LinkType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
 type NodeDescription_AttachedPortDescription_PortType int32
 
@@ -119,7 +171,7 @@ func (x NodeDescription_AttachedPortDescription_PortType) String() string {
 	return proto.EnumName(NodeDescription_AttachedPortDescription_PortType_name, int32(x))
 }
 func (NodeDescription_AttachedPortDescription_PortType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{10, 1, 0}
+	return fileDescriptor0, []int{15, 1, 0}
 }
 
 type Empty struct {
@@ -130,6 +182,131 @@ func (m *Empty) String() string            { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()               {}
 func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
+type SessionIdentifier struct {
+	Session string `protobuf:"bytes,1,opt,name=session" json:"session,omitempty"`
+}
+
+func (m *SessionIdentifier) Reset()                    { *m = SessionIdentifier{} }
+func (m *SessionIdentifier) String() string            { return proto.CompactTextString(m) }
+func (*SessionIdentifier) ProtoMessage()               {}
+func (*SessionIdentifier) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *SessionIdentifier) GetSession() string {
+	if m != nil {
+		return m.Session
+	}
+	return ""
+}
+
+type LeaseResponse struct {
+	Base *BaseResponse `protobuf:"bytes,1,opt,name=base" json:"base,omitempty"`
+	// time in milliseconds the session lives without renewal, 0 means that leases are disabled
+	LeaseMillis int64 `protobuf:"varint,2,opt,name=leaseMillis" json:"leaseMillis,omitempty"`
+}
+
+func (m *LeaseResponse) Reset()                    { *m = LeaseResponse{} }
+func (m *LeaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*LeaseResponse) ProtoMessage()               {}
+func (*LeaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *LeaseResponse) GetBase() *BaseResponse {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *LeaseResponse) GetLeaseMillis() int64 {
+	if m != nil {
+		return m.LeaseMillis
+	}
+	return 0
+}
+
+type ProcessGraphRequest struct {
+	Ids []*NodeIdentifier `protobuf:"bytes,1,rep,name=ids" json:"ids,omitempty"`
+	// if set nodes are processed repeatedly until port values converge
+	Iterate bool `protobuf:"varint,2,opt,name=iterate" json:"iterate,omitempty"`
+	// maximal port value change between iterations at convergence, server default is used if it is not set
+	Precision float64 `protobuf:"fixed64,3,opt,name=precision" json:"precision,omitempty"`
+	// server default is used if it is not set
+	MaxIterations int32 `protobuf:"varint,4,opt,name=maxIterations" json:"maxIterations,omitempty"`
+}
+
+func (m *ProcessGraphRequest) Reset()                    { *m = ProcessGraphRequest{} }
+func (m *ProcessGraphRequest) String() string            { return proto.CompactTextString(m) }
+func (*ProcessGraphRequest) ProtoMessage()               {}
+func (*ProcessGraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ProcessGraphRequest) GetIds() []*NodeIdentifier {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+func (m *ProcessGraphRequest) GetIterate() bool {
+	if m != nil {
+		return m.Iterate
+	}
+	return false
+}
+
+func (m *ProcessGraphRequest) GetPrecision() float64 {
+	if m != nil {
+		return m.Precision
+	}
+	return 0
+}
+
+func (m *ProcessGraphRequest) GetMaxIterations() int32 {
+	if m != nil {
+		return m.MaxIterations
+	}
+	return 0
+}
+
+type ProcessGraphResponse struct {
+	Base *BaseResponse `protobuf:"bytes,1,opt,name=base" json:"base,omitempty"`
+	// identifiers of the nodes in order they were processed
+	CallOrder  []*NodeIdentifier `protobuf:"bytes,2,rep,name=callOrder" json:"callOrder,omitempty"`
+	Iterations int32             `protobuf:"varint,3,opt,name=iterations" json:"iterations,omitempty"`
+	Converged  bool              `protobuf:"varint,4,opt,name=converged" json:"converged,omitempty"`
+}
+
+func (m *ProcessGraphResponse) Reset()                    { *m = ProcessGraphResponse{} }
+func (m *ProcessGraphResponse) String() string            { return proto.CompactTextString(m) }
+func (*ProcessGraphResponse) ProtoMessage()               {}
+func (*ProcessGraphResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *ProcessGraphResponse) GetBase() *BaseResponse {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *ProcessGraphResponse) GetCallOrder() []*NodeIdentifier {
+	if m != nil {
+		return m.CallOrder
+	}
+	return nil
+}
+
+func (m *ProcessGraphResponse) GetIterations() int32 {
+	if m != nil {
+		return m.Iterations
+	}
+	return 0
+}
+
+func (m *ProcessGraphResponse) GetConverged() bool {
+	if m != nil {
+		return m.Converged
+	}
+	return false
+}
+
 type PortStateResponse struct {
 	Base  *BaseResponse                     `protobuf:"bytes,1,opt,name=base" json:"base,omitempty"`
 	Items []*PortStateResponse_UnitResponse `protobuf:"bytes,2,rep,name=items" json:"items,omitempty"`
@@ -138,7 +315,7 @@ type PortStateResponse struct {
 func (m *PortStateResponse) Reset()                    { *m = PortStateResponse{} }
 func (m *PortStateResponse) String() string            { return proto.CompactTextString(m) }
 func (*PortStateResponse) ProtoMessage()               {}
-func (*PortStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (*PortStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *PortStateResponse) GetBase() *BaseResponse {
 	if m != nil {
@@ -164,7 +341,7 @@ func (m *PortStateResponse_UnitResponse) Reset()         { *m = PortStateRespons
 func (m *PortStateResponse_UnitResponse) String() string { return proto.CompactTextString(m) }
 func (*PortStateResponse_UnitResponse) ProtoMessage()    {}
 func (*PortStateResponse_UnitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{1, 0}
+	return fileDescriptor0, []int{5, 0}
 }
 
 func (m *PortStateResponse_UnitResponse) GetBase() *BaseResponse {
@@ -188,6 +365,65 @@ func (m *PortStateResponse_UnitResponse) GetState() *PortState {
 	return nil
 }
 
+type NodeListResponse struct {
+	Base  *BaseResponse                    `protobuf:"bytes,1,opt,name=base" json:"base,omitempty"`
+	Items []*NodeListResponse_UnitResponse `protobuf:"bytes,2,rep,name=items" json:"items,omitempty"`
+	// token of the next page, empty if there are no more nodes
+	NextPageToken string `protobuf:"bytes,3,opt,name=nextPageToken" json:"nextPageToken,omitempty"`
+}
+
+func (m *NodeListResponse) Reset()                    { *m = NodeListResponse{} }
+func (m *NodeListResponse) String() string            { return proto.CompactTextString(m) }
+func (*NodeListResponse) ProtoMessage()               {}
+func (*NodeListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *NodeListResponse) GetBase() *BaseResponse {
+	if m != nil {
+		return m.Base
+	}
+	return nil
+}
+
+func (m *NodeListResponse) GetItems() []*NodeListResponse_UnitResponse {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *NodeListResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type NodeListResponse_UnitResponse struct {
+	Identifier *NodeIdentifier `protobuf:"bytes,1,opt,name=identifier" json:"identifier,omitempty"`
+	Name       string          `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *NodeListResponse_UnitResponse) Reset()         { *m = NodeListResponse_UnitResponse{} }
+func (m *NodeListResponse_UnitResponse) String() string { return proto.CompactTextString(m) }
+func (*NodeListResponse_UnitResponse) ProtoMessage()    {}
+func (*NodeListResponse_UnitResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{6, 0}
+}
+
+func (m *NodeListResponse_UnitResponse) GetIdentifier() *NodeIdentifier {
+	if m != nil {
+		return m.Identifier
+	}
+	return nil
+}
+
+func (m *NodeListResponse_UnitResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 type NodeStateResponse struct {
 	Base  *BaseResponse                     `protobuf:"bytes,1,opt,name=base" json:"base,omitempty"`
 	Items []*NodeStateResponse_UnitResponse `protobuf:"bytes,2,rep,name=items" json:"items,omitempty"`
@@ -196,7 +432,7 @@ type NodeStateResponse struct {
 func (m *NodeStateResponse) Reset()                    { *m = NodeStateResponse{} }
 func (m *NodeStateResponse) String() string            { return proto.CompactTextString(m) }
 func (*NodeStateResponse) ProtoMessage()               {}
-func (*NodeStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*NodeStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *NodeStateResponse) GetBase() *BaseResponse {
 	if m != nil {
@@ -222,7 +458,7 @@ func (m *NodeStateResponse_UnitResponse) Reset()         { *m = NodeStateRespons
 func (m *NodeStateResponse_UnitResponse) String() string { return proto.CompactTextString(m) }
 func (*NodeStateResponse_UnitResponse) ProtoMessage()    {}
 func (*NodeStateResponse_UnitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{2, 0}
+	return fileDescriptor0, []int{7, 0}
 }
 
 func (m *NodeStateResponse_UnitResponse) GetBase() *BaseResponse {
@@ -254,7 +490,7 @@ type PortModifyResponse struct {
 func (m *PortModifyResponse) Reset()                    { *m = PortModifyResponse{} }
 func (m *PortModifyResponse) String() string            { return proto.CompactTextString(m) }
 func (*PortModifyResponse) ProtoMessage()               {}
-func (*PortModifyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*PortModifyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *PortModifyResponse) GetBase() *BaseResponse {
 	if m != nil {
@@ -279,7 +515,7 @@ func (m *PortModifyResponse_UnitResponse) Reset()         { *m = PortModifyRespo
 func (m *PortModifyResponse_UnitResponse) String() string { return proto.CompactTextString(m) }
 func (*PortModifyResponse_UnitResponse) ProtoMessage()    {}
 func (*PortModifyResponse_UnitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{3, 0}
+	return fileDescriptor0, []int{8, 0}
 }
 
 func (m *PortModifyResponse_UnitResponse) GetIdentifier() *PortIdentifier {
@@ -299,12 +535,14 @@ func (m *PortModifyResponse_UnitResponse) GetBase() *BaseResponse {
 type NodeModifyResponse struct {
 	Base  *BaseResponse                      `protobuf:"bytes,1,opt,name=base" json:"base,omitempty"`
 	Items []*NodeModifyResponse_UnitResponse `protobuf:"bytes,2,rep,name=items" json:"items,omitempty"`
+	// set if atomic request failed and all its applied items were reverted
+	RolledBack bool `protobuf:"varint,3,opt,name=rolledBack" json:"rolledBack,omitempty"`
 }
 
 func (m *NodeModifyResponse) Reset()                    { *m = NodeModifyResponse{} }
 func (m *NodeModifyResponse) String() string            { return proto.CompactTextString(m) }
 func (*NodeModifyResponse) ProtoMessage()               {}
-func (*NodeModifyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*NodeModifyResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *NodeModifyResponse) GetBase() *BaseResponse {
 	if m != nil {
@@ -320,17 +558,26 @@ func (m *NodeModifyResponse) GetItems() []*NodeModifyResponse_UnitResponse {
 	return nil
 }
 
+func (m *NodeModifyResponse) GetRolledBack() bool {
+	if m != nil {
+		return m.RolledBack
+	}
+	return false
+}
+
 type NodeModifyResponse_UnitResponse struct {
 	// multiple nodes for link request
 	Identifiers []*NodeIdentifier `protobuf:"bytes,1,rep,name=identifiers" json:"identifiers,omitempty"`
 	Base        *BaseResponse     `protobuf:"bytes,2,opt,name=base" json:"base,omitempty"`
+	// time spent on the item in microseconds, set by ProcessStream only
+	DurationMicros int64 `protobuf:"varint,3,opt,name=durationMicros" json:"durationMicros,omitempty"`
 }
 
 func (m *NodeModifyResponse_UnitResponse) Reset()         { *m = NodeModifyResponse_UnitResponse{} }
 func (m *NodeModifyResponse_UnitResponse) String() string { return proto.CompactTextString(m) }
 func (*NodeModifyResponse_UnitResponse) ProtoMessage()    {}
 func (*NodeModifyResponse_UnitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{4, 0}
+	return fileDescriptor0, []int{9, 0}
 }
 
 func (m *NodeModifyResponse_UnitResponse) GetIdentifiers() []*NodeIdentifier {
@@ -347,22 +594,29 @@ func (m *NodeModifyResponse_UnitResponse) GetBase() *BaseResponse {
 	return nil
 }
 
+func (m *NodeModifyResponse_UnitResponse) GetDurationMicros() int64 {
+	if m != nil {
+		return m.DurationMicros
+	}
+	return 0
+}
+
 type BaseResponse struct {
-	Status      int32    `protobuf:"varint,1,opt,name=status" json:"status,omitempty"`
-	Description string   `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
-	Messages    []string `protobuf:"bytes,3,rep,name=messages" json:"messages,omitempty"`
+	Status      StatusCode `protobuf:"varint,1,opt,name=status,enum=nodeservice.StatusCode" json:"status,omitempty"`
+	Description string     `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	Messages    []string   `protobuf:"bytes,3,rep,name=messages" json:"messages,omitempty"`
 }
 
 func (m *BaseResponse) Reset()                    { *m = BaseResponse{} }
 func (m *BaseResponse) String() string            { return proto.CompactTextString(m) }
 func (*BaseResponse) ProtoMessage()               {}
-func (*BaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*BaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
-func (m *BaseResponse) GetStatus() int32 {
+func (m *BaseResponse) GetStatus() StatusCode {
 	if m != nil {
 		return m.Status
 	}
-	return 0
+	return StatusCode_UNSET
 }
 
 func (m *BaseResponse) GetDescription() string {
@@ -389,7 +643,7 @@ type NodeState struct {
 func (m *NodeState) Reset()                    { *m = NodeState{} }
 func (m *NodeState) String() string            { return proto.CompactTextString(m) }
 func (*NodeState) ProtoMessage()               {}
-func (*NodeState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*NodeState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *NodeState) GetName() string {
 	if m != nil {
@@ -427,7 +681,7 @@ type PortState struct {
 func (m *PortState) Reset()                    { *m = PortState{} }
 func (m *PortState) String() string            { return proto.CompactTextString(m) }
 func (*PortState) ProtoMessage()               {}
-func (*PortState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*PortState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *PortState) GetTag() string {
 	if m != nil {
@@ -451,7 +705,7 @@ type State struct {
 func (m *State) Reset()                    { *m = State{} }
 func (m *State) String() string            { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()               {}
-func (*State) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*State) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *State) GetNumValues() map[string]float64 {
 	if m != nil {
@@ -475,7 +729,7 @@ type ServiceDescription struct {
 func (m *ServiceDescription) Reset()                    { *m = ServiceDescription{} }
 func (m *ServiceDescription) String() string            { return proto.CompactTextString(m) }
 func (*ServiceDescription) ProtoMessage()               {}
-func (*ServiceDescription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*ServiceDescription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *ServiceDescription) GetDescription() string {
 	if m != nil {
@@ -500,7 +754,7 @@ type NodeDescription struct {
 func (m *NodeDescription) Reset()                    { *m = NodeDescription{} }
 func (m *NodeDescription) String() string            { return proto.CompactTextString(m) }
 func (*NodeDescription) ProtoMessage()               {}
-func (*NodeDescription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*NodeDescription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *NodeDescription) GetNodeType() string {
 	if m != nil {
@@ -531,7 +785,7 @@ func (m *NodeDescription_ContextState) Reset()         { *m = NodeDescription_Co
 func (m *NodeDescription_ContextState) String() string { return proto.CompactTextString(m) }
 func (*NodeDescription_ContextState) ProtoMessage()    {}
 func (*NodeDescription_ContextState) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{10, 0}
+	return fileDescriptor0, []int{15, 0}
 }
 
 func (m *NodeDescription_ContextState) GetPorts() []*NodeDescription_AttachedPortDescription {
@@ -552,7 +806,7 @@ func (m *NodeDescription_AttachedPortDescription) Reset() {
 func (m *NodeDescription_AttachedPortDescription) String() string { return proto.CompactTextString(m) }
 func (*NodeDescription_AttachedPortDescription) ProtoMessage()    {}
 func (*NodeDescription_AttachedPortDescription) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{10, 1}
+	return fileDescriptor0, []int{15, 1}
 }
 
 func (m *NodeDescription_AttachedPortDescription) GetDescription() *PortDescription {
@@ -577,7 +831,7 @@ type PortDescription struct {
 func (m *PortDescription) Reset()                    { *m = PortDescription{} }
 func (m *PortDescription) String() string            { return proto.CompactTextString(m) }
 func (*PortDescription) ProtoMessage()               {}
-func (*PortDescription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*PortDescription) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *PortDescription) GetPrefix() string {
 	if m != nil {
@@ -595,12 +849,14 @@ func (m *PortDescription) GetIsMulti() bool {
 
 type LinkRequest struct {
 	Items []*LinkRequest_UnitRequest `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+	// if set failure of any item reverts all the items of the request
+	Atomic bool `protobuf:"varint,2,opt,name=atomic" json:"atomic,omitempty"`
 }
 
 func (m *LinkRequest) Reset()                    { *m = LinkRequest{} }
 func (m *LinkRequest) String() string            { return proto.CompactTextString(m) }
 func (*LinkRequest) ProtoMessage()               {}
-func (*LinkRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*LinkRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *LinkRequest) GetItems() []*LinkRequest_UnitRequest {
 	if m != nil {
@@ -609,6 +865,13 @@ func (m *LinkRequest) GetItems() []*LinkRequest_UnitRequest {
 	return nil
 }
 
+func (m *LinkRequest) GetAtomic() bool {
+	if m != nil {
+		return m.Atomic
+	}
+	return false
+}
+
 type LinkRequest_UnitRequest struct {
 	LinkType LinkType        `protobuf:"varint,1,opt,name=linkType,enum=nodeservice.LinkType" json:"linkType,omitempty"`
 	Id1      *PortIdentifier `protobuf:"bytes,2,opt,name=id1" json:"id1,omitempty"`
@@ -618,7 +881,7 @@ type LinkRequest_UnitRequest struct {
 func (m *LinkRequest_UnitRequest) Reset()                    { *m = LinkRequest_UnitRequest{} }
 func (m *LinkRequest_UnitRequest) String() string            { return proto.CompactTextString(m) }
 func (*LinkRequest_UnitRequest) ProtoMessage()               {}
-func (*LinkRequest_UnitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12, 0} }
+func (*LinkRequest_UnitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17, 0} }
 
 func (m *LinkRequest_UnitRequest) GetLinkType() LinkType {
 	if m != nil {
@@ -643,12 +906,14 @@ func (m *LinkRequest_UnitRequest) GetId2() *PortIdentifier {
 
 type NodeUpdateRequest struct {
 	Items []*NodeUpdateRequest_UnitRequest `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+	// if set failure of any item reverts all the items of the request
+	Atomic bool `protobuf:"varint,2,opt,name=atomic" json:"atomic,omitempty"`
 }
 
 func (m *NodeUpdateRequest) Reset()                    { *m = NodeUpdateRequest{} }
 func (m *NodeUpdateRequest) String() string            { return proto.CompactTextString(m) }
 func (*NodeUpdateRequest) ProtoMessage()               {}
-func (*NodeUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*NodeUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *NodeUpdateRequest) GetItems() []*NodeUpdateRequest_UnitRequest {
 	if m != nil {
@@ -657,6 +922,13 @@ func (m *NodeUpdateRequest) GetItems() []*NodeUpdateRequest_UnitRequest {
 	return nil
 }
 
+func (m *NodeUpdateRequest) GetAtomic() bool {
+	if m != nil {
+		return m.Atomic
+	}
+	return false
+}
+
 type NodeUpdateRequest_UnitRequest struct {
 	Identifier *NodeIdentifier `protobuf:"bytes,1,opt,name=identifier" json:"identifier,omitempty"`
 	Data       *RequestData    `protobuf:"bytes,2,opt,name=data" json:"data,omitempty"`
@@ -666,7 +938,7 @@ func (m *NodeUpdateRequest_UnitRequest) Reset()         { *m = NodeUpdateRequest
 func (m *NodeUpdateRequest_UnitRequest) String() string { return proto.CompactTextString(m) }
 func (*NodeUpdateRequest_UnitRequest) ProtoMessage()    {}
 func (*NodeUpdateRequest_UnitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{13, 0}
+	return fileDescriptor0, []int{18, 0}
 }
 
 func (m *NodeUpdateRequest_UnitRequest) GetIdentifier() *NodeIdentifier {
@@ -690,7 +962,7 @@ type PortUpdateRequest struct {
 func (m *PortUpdateRequest) Reset()                    { *m = PortUpdateRequest{} }
 func (m *PortUpdateRequest) String() string            { return proto.CompactTextString(m) }
 func (*PortUpdateRequest) ProtoMessage()               {}
-func (*PortUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*PortUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *PortUpdateRequest) GetItems() []*PortUpdateRequest_UnitRequest {
 	if m != nil {
@@ -708,7 +980,7 @@ func (m *PortUpdateRequest_UnitRequest) Reset()         { *m = PortUpdateRequest
 func (m *PortUpdateRequest_UnitRequest) String() string { return proto.CompactTextString(m) }
 func (*PortUpdateRequest_UnitRequest) ProtoMessage()    {}
 func (*PortUpdateRequest_UnitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{14, 0}
+	return fileDescriptor0, []int{19, 0}
 }
 
 func (m *PortUpdateRequest_UnitRequest) GetIdentifier() *PortIdentifier {
@@ -732,7 +1004,7 @@ type PortStateRequest struct {
 func (m *PortStateRequest) Reset()                    { *m = PortStateRequest{} }
 func (m *PortStateRequest) String() string            { return proto.CompactTextString(m) }
 func (*PortStateRequest) ProtoMessage()               {}
-func (*PortStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*PortStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *PortStateRequest) GetItems() []*PortStateRequest_UnitRequest {
 	if m != nil {
@@ -751,7 +1023,7 @@ func (m *PortStateRequest_UnitRequest) Reset()         { *m = PortStateRequest_U
 func (m *PortStateRequest_UnitRequest) String() string { return proto.CompactTextString(m) }
 func (*PortStateRequest_UnitRequest) ProtoMessage()    {}
 func (*PortStateRequest_UnitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{15, 0}
+	return fileDescriptor0, []int{20, 0}
 }
 
 func (m *PortStateRequest_UnitRequest) GetIdentifier() *PortIdentifier {
@@ -768,6 +1040,57 @@ func (m *PortStateRequest_UnitRequest) GetRequiredFields() []string {
 	return nil
 }
 
+type NodeListRequest struct {
+	Session string `protobuf:"bytes,1,opt,name=session" json:"session,omitempty"`
+	// optional filters, nodes are returned if they match all the filters set
+	NodeType   string `protobuf:"bytes,2,opt,name=nodeType" json:"nodeType,omitempty"`
+	NamePrefix string `protobuf:"bytes,3,opt,name=namePrefix" json:"namePrefix,omitempty"`
+	// maximal number of nodes in response, server default is used if it is not set
+	PageSize int32 `protobuf:"varint,4,opt,name=pageSize" json:"pageSize,omitempty"`
+	// nextPageToken of the previous response, empty for the first page
+	PageToken string `protobuf:"bytes,5,opt,name=pageToken" json:"pageToken,omitempty"`
+}
+
+func (m *NodeListRequest) Reset()                    { *m = NodeListRequest{} }
+func (m *NodeListRequest) String() string            { return proto.CompactTextString(m) }
+func (*NodeListRequest) ProtoMessage()               {}
+func (*NodeListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *NodeListRequest) GetSession() string {
+	if m != nil {
+		return m.Session
+	}
+	return ""
+}
+
+func (m *NodeListRequest) GetNodeType() string {
+	if m != nil {
+		return m.NodeType
+	}
+	return ""
+}
+
+func (m *NodeListRequest) GetNamePrefix() string {
+	if m != nil {
+		return m.NamePrefix
+	}
+	return ""
+}
+
+func (m *NodeListRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *NodeListRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
 type NodeStateRequest struct {
 	Items []*NodeStateRequest_UnitRequest `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
 }
@@ -775,7 +1098,7 @@ type NodeStateRequest struct {
 func (m *NodeStateRequest) Reset()                    { *m = NodeStateRequest{} }
 func (m *NodeStateRequest) String() string            { return proto.CompactTextString(m) }
 func (*NodeStateRequest) ProtoMessage()               {}
-func (*NodeStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*NodeStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *NodeStateRequest) GetItems() []*NodeStateRequest_UnitRequest {
 	if m != nil {
@@ -794,7 +1117,7 @@ func (m *NodeStateRequest_UnitRequest) Reset()         { *m = NodeStateRequest_U
 func (m *NodeStateRequest_UnitRequest) String() string { return proto.CompactTextString(m) }
 func (*NodeStateRequest_UnitRequest) ProtoMessage()    {}
 func (*NodeStateRequest_UnitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{16, 0}
+	return fileDescriptor0, []int{22, 0}
 }
 
 func (m *NodeStateRequest_UnitRequest) GetIdentifier() *NodeIdentifier {
@@ -813,12 +1136,16 @@ func (m *NodeStateRequest_UnitRequest) GetRequiredFields() []string {
 
 type NodeCreateRequest struct {
 	Items []*NodeCreateRequest_UnitRequest `protobuf:"bytes,1,rep,name=items" json:"items,omitempty"`
+	// if set failure of any item reverts all the items of the request
+	Atomic bool `protobuf:"varint,2,opt,name=atomic" json:"atomic,omitempty"`
+	// session which created nodes belong to
+	Session string `protobuf:"bytes,3,opt,name=session" json:"session,omitempty"`
 }
 
 func (m *NodeCreateRequest) Reset()                    { *m = NodeCreateRequest{} }
 func (m *NodeCreateRequest) String() string            { return proto.CompactTextString(m) }
 func (*NodeCreateRequest) ProtoMessage()               {}
-func (*NodeCreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*NodeCreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *NodeCreateRequest) GetItems() []*NodeCreateRequest_UnitRequest {
 	if m != nil {
@@ -827,17 +1154,33 @@ func (m *NodeCreateRequest) GetItems() []*NodeCreateRequest_UnitRequest {
 	return nil
 }
 
+func (m *NodeCreateRequest) GetAtomic() bool {
+	if m != nil {
+		return m.Atomic
+	}
+	return false
+}
+
+func (m *NodeCreateRequest) GetSession() string {
+	if m != nil {
+		return m.Session
+	}
+	return ""
+}
+
 type NodeCreateRequest_UnitRequest struct {
 	NodeName string       `protobuf:"bytes,1,opt,name=nodeName" json:"nodeName,omitempty"`
 	NodeType string       `protobuf:"bytes,2,opt,name=nodeType" json:"nodeType,omitempty"`
 	Data     *RequestData `protobuf:"bytes,3,opt,name=data" json:"data,omitempty"`
+	// maps multiport prefix to its cardinality
+	MultiPorts map[string]int32 `protobuf:"bytes,4,rep,name=multiPorts" json:"multiPorts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
 }
 
 func (m *NodeCreateRequest_UnitRequest) Reset()         { *m = NodeCreateRequest_UnitRequest{} }
 func (m *NodeCreateRequest_UnitRequest) String() string { return proto.CompactTextString(m) }
 func (*NodeCreateRequest_UnitRequest) ProtoMessage()    {}
 func (*NodeCreateRequest_UnitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{17, 0}
+	return fileDescriptor0, []int{23, 0}
 }
 
 func (m *NodeCreateRequest_UnitRequest) GetNodeName() string {
@@ -861,16 +1204,24 @@ func (m *NodeCreateRequest_UnitRequest) GetData() *RequestData {
 	return nil
 }
 
+func (m *NodeCreateRequest_UnitRequest) GetMultiPorts() map[string]int32 {
+	if m != nil {
+		return m.MultiPorts
+	}
+	return nil
+}
+
 type PortIdentifier struct {
 	NodeIdentifier *NodeIdentifier `protobuf:"bytes,1,opt,name=nodeIdentifier" json:"nodeIdentifier,omitempty"`
-	NodeName       string          `protobuf:"bytes,2,opt,name=nodeName" json:"nodeName,omitempty"`
-	PortTag        string          `protobuf:"bytes,3,opt,name=portTag" json:"portTag,omitempty"`
+	// name of the node, used to find node in session of nodeIdentifier if its id is not set
+	NodeName string `protobuf:"bytes,2,opt,name=nodeName" json:"nodeName,omitempty"`
+	PortTag  string `protobuf:"bytes,3,opt,name=portTag" json:"portTag,omitempty"`
 }
 
 func (m *PortIdentifier) Reset()                    { *m = PortIdentifier{} }
 func (m *PortIdentifier) String() string            { return proto.CompactTextString(m) }
 func (*PortIdentifier) ProtoMessage()               {}
-func (*PortIdentifier) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*PortIdentifier) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *PortIdentifier) GetNodeIdentifier() *NodeIdentifier {
 	if m != nil {
@@ -900,7 +1251,7 @@ type NodeIdentifiers struct {
 func (m *NodeIdentifiers) Reset()                    { *m = NodeIdentifiers{} }
 func (m *NodeIdentifiers) String() string            { return proto.CompactTextString(m) }
 func (*NodeIdentifiers) ProtoMessage()               {}
-func (*NodeIdentifiers) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*NodeIdentifiers) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *NodeIdentifiers) GetIds() []*NodeIdentifier {
 	if m != nil {
@@ -912,12 +1263,16 @@ func (m *NodeIdentifiers) GetIds() []*NodeIdentifier {
 type NodeIdentifier struct {
 	Id       int32  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
 	NodeType string `protobuf:"bytes,2,opt,name=nodeType" json:"nodeType,omitempty"`
+	// node can be accessed only by identifiers of its own session
+	Session string `protobuf:"bytes,3,opt,name=session" json:"session,omitempty"`
+	// name of the node, used to find node in the session if id is not set
+	Name string `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
 }
 
 func (m *NodeIdentifier) Reset()                    { *m = NodeIdentifier{} }
 func (m *NodeIdentifier) String() string            { return proto.CompactTextString(m) }
 func (*NodeIdentifier) ProtoMessage()               {}
-func (*NodeIdentifier) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*NodeIdentifier) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *NodeIdentifier) GetId() int32 {
 	if m != nil {
@@ -933,6 +1288,20 @@ func (m *NodeIdentifier) GetNodeType() string {
 	return ""
 }
 
+func (m *NodeIdentifier) GetSession() string {
+	if m != nil {
+		return m.Session
+	}
+	return ""
+}
+
+func (m *NodeIdentifier) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
 type RequestData struct {
 	DArgs   []float64          `protobuf:"fixed64,1,rep,packed,name=dArgs" json:"dArgs,omitempty"`
 	SArgs   []string           `protobuf:"bytes,2,rep,name=sArgs" json:"sArgs,omitempty"`
@@ -943,7 +1312,7 @@ type RequestData struct {
 func (m *RequestData) Reset()                    { *m = RequestData{} }
 func (m *RequestData) String() string            { return proto.CompactTextString(m) }
 func (*RequestData) ProtoMessage()               {}
-func (*RequestData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*RequestData) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *RequestData) GetDArgs() []float64 {
 	if m != nil {
@@ -975,8 +1344,14 @@ func (m *RequestData) GetSKwargs() map[string]string {
 
 func init() {
 	proto.RegisterType((*Empty)(nil), "nodeservice.Empty")
+	proto.RegisterType((*SessionIdentifier)(nil), "nodeservice.SessionIdentifier")
+	proto.RegisterType((*LeaseResponse)(nil), "nodeservice.LeaseResponse")
+	proto.RegisterType((*ProcessGraphRequest)(nil), "nodeservice.ProcessGraphRequest")
+	proto.RegisterType((*ProcessGraphResponse)(nil), "nodeservice.ProcessGraphResponse")
 	proto.RegisterType((*PortStateResponse)(nil), "nodeservice.PortStateResponse")
 	proto.RegisterType((*PortStateResponse_UnitResponse)(nil), "nodeservice.PortStateResponse.UnitResponse")
+	proto.RegisterType((*NodeListResponse)(nil), "nodeservice.NodeListResponse")
+	proto.RegisterType((*NodeListResponse_UnitResponse)(nil), "nodeservice.NodeListResponse.UnitResponse")
 	proto.RegisterType((*NodeStateResponse)(nil), "nodeservice.NodeStateResponse")
 	proto.RegisterType((*NodeStateResponse_UnitResponse)(nil), "nodeservice.NodeStateResponse.UnitResponse")
 	proto.RegisterType((*PortModifyResponse)(nil), "nodeservice.PortModifyResponse")
@@ -1000,6 +1375,7 @@ func init() {
 	proto.RegisterType((*PortUpdateRequest_UnitRequest)(nil), "nodeservice.PortUpdateRequest.UnitRequest")
 	proto.RegisterType((*PortStateRequest)(nil), "nodeservice.PortStateRequest")
 	proto.RegisterType((*PortStateRequest_UnitRequest)(nil), "nodeservice.PortStateRequest.UnitRequest")
+	proto.RegisterType((*NodeListRequest)(nil), "nodeservice.NodeListRequest")
 	proto.RegisterType((*NodeStateRequest)(nil), "nodeservice.NodeStateRequest")
 	proto.RegisterType((*NodeStateRequest_UnitRequest)(nil), "nodeservice.NodeStateRequest.UnitRequest")
 	proto.RegisterType((*NodeCreateRequest)(nil), "nodeservice.NodeCreateRequest")
@@ -1008,6 +1384,7 @@ func init() {
 	proto.RegisterType((*NodeIdentifiers)(nil), "nodeservice.NodeIdentifiers")
 	proto.RegisterType((*NodeIdentifier)(nil), "nodeservice.NodeIdentifier")
 	proto.RegisterType((*RequestData)(nil), "nodeservice.RequestData")
+	proto.RegisterEnum("nodeservice.StatusCode", StatusCode_name, StatusCode_value)
 	proto.RegisterEnum("nodeservice.LinkType", LinkType_name, LinkType_value)
 	proto.RegisterEnum("nodeservice.NodeDescription_AttachedPortDescription_PortType", NodeDescription_AttachedPortDescription_PortType_name, NodeDescription_AttachedPortDescription_PortType_value)
 }
@@ -1027,11 +1404,23 @@ type NodeServiceClient interface {
 	UpdateNodes(ctx context.Context, in *NodeUpdateRequest, opts ...grpc.CallOption) (*NodeModifyResponse, error)
 	DeleteNodes(ctx context.Context, in *NodeIdentifiers, opts ...grpc.CallOption) (*NodeModifyResponse, error)
 	GetNodesState(ctx context.Context, in *NodeStateRequest, opts ...grpc.CallOption) (*NodeStateResponse, error)
+	ListNodes(ctx context.Context, in *NodeListRequest, opts ...grpc.CallOption) (*NodeListResponse, error)
 	GetPortsState(ctx context.Context, in *PortStateRequest, opts ...grpc.CallOption) (*PortStateResponse, error)
 	SetPortsState(ctx context.Context, in *PortUpdateRequest, opts ...grpc.CallOption) (*PortModifyResponse, error)
 	Process(ctx context.Context, in *NodeIdentifiers, opts ...grpc.CallOption) (*NodeModifyResponse, error)
+	// works as Process but sends result of every node as soon as it is processed
+	ProcessStream(ctx context.Context, in *NodeIdentifiers, opts ...grpc.CallOption) (NodeService_ProcessStreamClient, error)
+	// processes linked nodes in dependency order, optionally until port values converge
+	ProcessGraph(ctx context.Context, in *ProcessGraphRequest, opts ...grpc.CallOption) (*ProcessGraphResponse, error)
 	Link(ctx context.Context, in *LinkRequest, opts ...grpc.CallOption) (*NodeModifyResponse, error)
+	// link type of the request items is ignored
+	Unlink(ctx context.Context, in *LinkRequest, opts ...grpc.CallOption) (*NodeModifyResponse, error)
 	GetDescription(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServiceDescription, error)
+	// deletes all the nodes of the session
+	DeleteSession(ctx context.Context, in *SessionIdentifier, opts ...grpc.CallOption) (*NodeModifyResponse, error)
+	// prolongs lease of the session. Sessions which are not renewed in time are deleted by the server.
+	// Sessions without nodes and sessions with expired leases are reported as NOT_FOUND
+	RenewSession(ctx context.Context, in *SessionIdentifier, opts ...grpc.CallOption) (*LeaseResponse, error)
 }
 
 type nodeServiceClient struct {
@@ -1078,6 +1467,15 @@ func (c *nodeServiceClient) GetNodesState(ctx context.Context, in *NodeStateRequ
 	return out, nil
 }
 
+func (c *nodeServiceClient) ListNodes(ctx context.Context, in *NodeListRequest, opts ...grpc.CallOption) (*NodeListResponse, error) {
+	out := new(NodeListResponse)
+	err := grpc.Invoke(ctx, "/nodeservice.NodeService/ListNodes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeServiceClient) GetPortsState(ctx context.Context, in *PortStateRequest, opts ...grpc.CallOption) (*PortStateResponse, error) {
 	out := new(PortStateResponse)
 	err := grpc.Invoke(ctx, "/nodeservice.NodeService/GetPortsState", in, out, c.cc, opts...)
@@ -1105,6 +1503,47 @@ func (c *nodeServiceClient) Process(ctx context.Context, in *NodeIdentifiers, op
 	return out, nil
 }
 
+func (c *nodeServiceClient) ProcessStream(ctx context.Context, in *NodeIdentifiers, opts ...grpc.CallOption) (NodeService_ProcessStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_NodeService_serviceDesc.Streams[0], c.cc, "/nodeservice.NodeService/ProcessStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &nodeServiceProcessStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type NodeService_ProcessStreamClient interface {
+	Recv() (*NodeModifyResponse_UnitResponse, error)
+	grpc.ClientStream
+}
+
+type nodeServiceProcessStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeServiceProcessStreamClient) Recv() (*NodeModifyResponse_UnitResponse, error) {
+	m := new(NodeModifyResponse_UnitResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *nodeServiceClient) ProcessGraph(ctx context.Context, in *ProcessGraphRequest, opts ...grpc.CallOption) (*ProcessGraphResponse, error) {
+	out := new(ProcessGraphResponse)
+	err := grpc.Invoke(ctx, "/nodeservice.NodeService/ProcessGraph", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeServiceClient) Link(ctx context.Context, in *LinkRequest, opts ...grpc.CallOption) (*NodeModifyResponse, error) {
 	out := new(NodeModifyResponse)
 	err := grpc.Invoke(ctx, "/nodeservice.NodeService/Link", in, out, c.cc, opts...)
@@ -1114,6 +1553,15 @@ func (c *nodeServiceClient) Link(ctx context.Context, in *LinkRequest, opts ...g
 	return out, nil
 }
 
+func (c *nodeServiceClient) Unlink(ctx context.Context, in *LinkRequest, opts ...grpc.CallOption) (*NodeModifyResponse, error) {
+	out := new(NodeModifyResponse)
+	err := grpc.Invoke(ctx, "/nodeservice.NodeService/Unlink", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nodeServiceClient) GetDescription(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServiceDescription, error) {
 	out := new(ServiceDescription)
 	err := grpc.Invoke(ctx, "/nodeservice.NodeService/GetDescription", in, out, c.cc, opts...)
@@ -1123,6 +1571,24 @@ func (c *nodeServiceClient) GetDescription(ctx context.Context, in *Empty, opts
 	return out, nil
 }
 
+func (c *nodeServiceClient) DeleteSession(ctx context.Context, in *SessionIdentifier, opts ...grpc.CallOption) (*NodeModifyResponse, error) {
+	out := new(NodeModifyResponse)
+	err := grpc.Invoke(ctx, "/nodeservice.NodeService/DeleteSession", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nodeServiceClient) RenewSession(ctx context.Context, in *SessionIdentifier, opts ...grpc.CallOption) (*LeaseResponse, error) {
+	out := new(LeaseResponse)
+	err := grpc.Invoke(ctx, "/nodeservice.NodeService/RenewSession", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for NodeService service
 
 type NodeServiceServer interface {
@@ -1130,11 +1596,23 @@ type NodeServiceServer interface {
 	UpdateNodes(context.Context, *NodeUpdateRequest) (*NodeModifyResponse, error)
 	DeleteNodes(context.Context, *NodeIdentifiers) (*NodeModifyResponse, error)
 	GetNodesState(context.Context, *NodeStateRequest) (*NodeStateResponse, error)
+	ListNodes(context.Context, *NodeListRequest) (*NodeListResponse, error)
 	GetPortsState(context.Context, *PortStateRequest) (*PortStateResponse, error)
 	SetPortsState(context.Context, *PortUpdateRequest) (*PortModifyResponse, error)
 	Process(context.Context, *NodeIdentifiers) (*NodeModifyResponse, error)
+	// works as Process but sends result of every node as soon as it is processed
+	ProcessStream(*NodeIdentifiers, NodeService_ProcessStreamServer) error
+	// processes linked nodes in dependency order, optionally until port values converge
+	ProcessGraph(context.Context, *ProcessGraphRequest) (*ProcessGraphResponse, error)
 	Link(context.Context, *LinkRequest) (*NodeModifyResponse, error)
+	// link type of the request items is ignored
+	Unlink(context.Context, *LinkRequest) (*NodeModifyResponse, error)
 	GetDescription(context.Context, *Empty) (*ServiceDescription, error)
+	// deletes all the nodes of the session
+	DeleteSession(context.Context, *SessionIdentifier) (*NodeModifyResponse, error)
+	// prolongs lease of the session. Sessions which are not renewed in time are deleted by the server.
+	// Sessions without nodes and sessions with expired leases are reported as NOT_FOUND
+	RenewSession(context.Context, *SessionIdentifier) (*LeaseResponse, error)
 }
 
 func RegisterNodeServiceServer(s *grpc.Server, srv NodeServiceServer) {
@@ -1213,6 +1691,24 @@ func _NodeService_GetNodesState_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_ListNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).ListNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeservice.NodeService/ListNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).ListNodes(ctx, req.(*NodeListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodeService_GetPortsState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PortStateRequest)
 	if err := dec(in); err != nil {
@@ -1267,6 +1763,45 @@ func _NodeService_Process_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_ProcessStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NodeIdentifiers)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NodeServiceServer).ProcessStream(m, &nodeServiceProcessStreamServer{stream})
+}
+
+type NodeService_ProcessStreamServer interface {
+	Send(*NodeModifyResponse_UnitResponse) error
+	grpc.ServerStream
+}
+
+type nodeServiceProcessStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *nodeServiceProcessStreamServer) Send(m *NodeModifyResponse_UnitResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _NodeService_ProcessGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessGraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).ProcessGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeservice.NodeService/ProcessGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).ProcessGraph(ctx, req.(*ProcessGraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodeService_Link_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LinkRequest)
 	if err := dec(in); err != nil {
@@ -1285,6 +1820,24 @@ func _NodeService_Link_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_Unlink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).Unlink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeservice.NodeService/Unlink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).Unlink(ctx, req.(*LinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NodeService_GetDescription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -1303,6 +1856,42 @@ func _NodeService_GetDescription_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NodeService_DeleteSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).DeleteSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeservice.NodeService/DeleteSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).DeleteSession(ctx, req.(*SessionIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NodeService_RenewSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServiceServer).RenewSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/nodeservice.NodeService/RenewSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServiceServer).RenewSession(ctx, req.(*SessionIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _NodeService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "nodeservice.NodeService",
 	HandlerType: (*NodeServiceServer)(nil),
@@ -1323,6 +1912,10 @@ var _NodeService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetNodesState",
 			Handler:    _NodeService_GetNodesState_Handler,
 		},
+		{
+			MethodName: "ListNodes",
+			Handler:    _NodeService_ListNodes_Handler,
+		},
 		{
 			MethodName: "GetPortsState",
 			Handler:    _NodeService_GetPortsState_Handler,
@@ -1335,107 +1928,167 @@ var _NodeService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Process",
 			Handler:    _NodeService_Process_Handler,
 		},
+		{
+			MethodName: "ProcessGraph",
+			Handler:    _NodeService_ProcessGraph_Handler,
+		},
 		{
 			MethodName: "Link",
 			Handler:    _NodeService_Link_Handler,
 		},
+		{
+			MethodName: "Unlink",
+			Handler:    _NodeService_Unlink_Handler,
+		},
 		{
 			MethodName: "GetDescription",
 			Handler:    _NodeService_GetDescription_Handler,
 		},
+		{
+			MethodName: "DeleteSession",
+			Handler:    _NodeService_DeleteSession_Handler,
+		},
+		{
+			MethodName: "RenewSession",
+			Handler:    _NodeService_RenewSession_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ProcessStream",
+			Handler:       _NodeService_ProcessStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "node_service.proto",
 }
 
 func init() { proto.RegisterFile("node_service.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1374 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xcf, 0x73, 0xdb, 0xc4,
-	0x17, 0xb7, 0x64, 0x3b, 0xb6, 0x9e, 0x1c, 0xd7, 0xdd, 0xf9, 0xb6, 0x5f, 0x63, 0x4a, 0x09, 0x1a,
-	0x60, 0xd2, 0xd2, 0x7a, 0xa6, 0x86, 0x61, 0x98, 0x40, 0xc9, 0x0f, 0xdb, 0x49, 0xd3, 0x26, 0x8e,
-	0x91, 0x1d, 0x60, 0x7a, 0xc9, 0x28, 0xd6, 0xc6, 0x15, 0x71, 0x64, 0x57, 0x92, 0x4b, 0xc2, 0x70,
-	0x63, 0x38, 0x71, 0xe2, 0xc6, 0x89, 0x13, 0x07, 0x8e, 0xcc, 0xf0, 0x47, 0x30, 0xc3, 0x85, 0x03,
-	0xff, 0x05, 0x57, 0xfe, 0x01, 0x66, 0x77, 0x25, 0x65, 0x57, 0xb2, 0x1d, 0x39, 0xa4, 0xc3, 0x4d,
-	0xbb, 0x7a, 0xef, 0xb3, 0xef, 0xf3, 0xd9, 0xf7, 0x76, 0x9f, 0x04, 0xc8, 0x1e, 0x9a, 0xf8, 0xc0,
-	0xc5, 0xce, 0x0b, 0xab, 0x87, 0xab, 0x23, 0x67, 0xe8, 0x0d, 0x91, 0x4a, 0xe6, 0xfc, 0x29, 0x2d,
-	0x07, 0xd9, 0xe6, 0xc9, 0xc8, 0x3b, 0xd3, 0x7e, 0x91, 0xe1, 0x7a, 0x7b, 0xe8, 0x78, 0x1d, 0xcf,
-	0xf0, 0xb0, 0x8e, 0xdd, 0xd1, 0xd0, 0x76, 0x31, 0xba, 0x0f, 0x99, 0x43, 0xc3, 0xc5, 0x65, 0x69,
-	0x49, 0x5a, 0x56, 0x6b, 0xaf, 0x54, 0x39, 0xd7, 0xea, 0x86, 0xe1, 0x86, 0x86, 0x3a, 0x35, 0x43,
-	0xeb, 0x90, 0xb5, 0x3c, 0x7c, 0xe2, 0x96, 0xe5, 0xa5, 0xf4, 0xb2, 0x5a, 0x7b, 0x47, 0xb0, 0x8f,
-	0xa1, 0x57, 0xf7, 0x6d, 0xcb, 0x0b, 0x11, 0x98, 0x67, 0xe5, 0x67, 0x09, 0x0a, 0xfc, 0xfc, 0xbc,
-	0x21, 0x7c, 0x08, 0x60, 0x99, 0xd8, 0xf6, 0xac, 0x23, 0x0b, 0x3b, 0x65, 0x99, 0x3a, 0xbd, 0x1a,
-	0x8b, 0x63, 0x3b, 0x34, 0xd1, 0x39, 0x73, 0x74, 0x0f, 0xb2, 0x2e, 0x89, 0xb0, 0x9c, 0xa6, 0x7e,
-	0x37, 0xa7, 0xc4, 0xcf, 0x8c, 0xa8, 0x64, 0xad, 0xa1, 0x89, 0x5f, 0x9e, 0x64, 0x31, 0xf4, 0xff,
-	0x48, 0x32, 0x12, 0xc7, 0x65, 0x24, 0x3b, 0x8f, 0xdf, 0x97, 0xec, 0x1b, 0x19, 0x10, 0xd1, 0x71,
-	0x77, 0x68, 0x5a, 0x47, 0x67, 0x97, 0x0d, 0x78, 0x43, 0xd4, 0xec, 0x5e, 0x6c, 0x9b, 0x44, 0xf8,
-	0x89, 0xa2, 0x7d, 0x15, 0xd1, 0x4c, 0x14, 0x41, 0x9a, 0x2f, 0x6f, 0x82, 0xf8, 0xe5, 0x44, 0xf1,
-	0x6b, 0xdf, 0xca, 0x80, 0x88, 0x34, 0x2f, 0x51, 0x85, 0x38, 0xfc, 0x44, 0x15, 0xbe, 0x8e, 0xa8,
-	0xf0, 0x10, 0xd4, 0x73, 0x5a, 0x6e, 0x59, 0xa2, 0xc8, 0x33, 0x73, 0x81, 0xb7, 0x9f, 0x57, 0x07,
-	0x13, 0x0a, 0xfc, 0x2c, 0xba, 0x09, 0x0b, 0x24, 0x4d, 0xc6, 0x2e, 0x95, 0x20, 0xab, 0xfb, 0x23,
-	0xb4, 0x04, 0xaa, 0x89, 0xdd, 0x9e, 0x63, 0x8d, 0x3c, 0x6b, 0x68, 0x53, 0x74, 0x45, 0xe7, 0xa7,
-	0x50, 0x05, 0xf2, 0x27, 0xd8, 0x75, 0x8d, 0x3e, 0x76, 0xcb, 0xe9, 0xa5, 0xf4, 0xb2, 0xa2, 0x87,
-	0x63, 0xed, 0x07, 0x19, 0x94, 0x30, 0x11, 0x11, 0x82, 0x8c, 0x6d, 0x9c, 0x30, 0x91, 0x15, 0x9d,
-	0x3e, 0xa3, 0xe5, 0x20, 0x87, 0x59, 0xdc, 0x48, 0x88, 0x9b, 0xcf, 0x5f, 0xf4, 0x3e, 0xc0, 0x28,
-	0x38, 0x06, 0xd8, 0x4a, 0xd3, 0x4f, 0x09, 0xce, 0x12, 0xad, 0x41, 0xbe, 0xf7, 0xcc, 0x1a, 0x98,
-	0x0e, 0xb6, 0xcb, 0x19, 0xea, 0xf5, 0xe6, 0xe4, 0x42, 0xa9, 0xd6, 0x7d, 0xb3, 0xa6, 0xed, 0x39,
-	0x67, 0x7a, 0xe8, 0x55, 0xe9, 0xc0, 0xa2, 0xf0, 0x0a, 0x95, 0x20, 0x7d, 0x8c, 0xcf, 0x7c, 0x1e,
-	0xe4, 0x91, 0x94, 0xe2, 0x0b, 0x63, 0x30, 0x0e, 0x68, 0x4c, 0x2d, 0x45, 0x6a, 0xb4, 0x22, 0x7f,
-	0x20, 0x69, 0x5b, 0xa0, 0x84, 0xf1, 0x12, 0x40, 0xcf, 0xe8, 0x07, 0x80, 0x9e, 0xd1, 0x4f, 0xae,
-	0x8b, 0xf6, 0xbd, 0x0c, 0x59, 0x86, 0xb2, 0x0a, 0x8a, 0x3d, 0x3e, 0xf9, 0x94, 0x2c, 0x11, 0xe4,
-	0xcf, 0x1b, 0x71, 0xbf, 0x6a, 0x2b, 0xb0, 0x61, 0x3c, 0xcf, 0x7d, 0xd0, 0x23, 0x28, 0xb8, 0x9e,
-	0x63, 0xd9, 0x7d, 0x1f, 0x43, 0x9e, 0x20, 0x17, 0xc3, 0xe8, 0x70, 0x66, 0x0c, 0x46, 0xf0, 0xac,
-	0x7c, 0x04, 0x45, 0x71, 0x99, 0x09, 0x9a, 0xfd, 0x8f, 0xd7, 0x4c, 0xe2, 0xb4, 0xa9, 0xac, 0xc2,
-	0xf5, 0xd8, 0x02, 0x17, 0x01, 0x28, 0xbc, 0xb8, 0x5f, 0x00, 0xea, 0xb0, 0x78, 0x1b, 0x5c, 0xa6,
-	0x46, 0x72, 0x59, 0x8a, 0xe7, 0x72, 0x0d, 0xb2, 0x94, 0xab, 0xcf, 0xfc, 0x56, 0x6c, 0x1b, 0x39,
-	0x38, 0x9d, 0x99, 0x6a, 0x3f, 0x65, 0xe0, 0x5a, 0xe4, 0x15, 0xa9, 0x09, 0x32, 0xd5, 0x3d, 0x1b,
-	0x05, 0xd9, 0x1e, 0x8e, 0x91, 0x0e, 0x0a, 0xa9, 0x40, 0xb2, 0xf9, 0xc1, 0x3a, 0xef, 0xcd, 0x5a,
-	0xa7, 0xba, 0xee, 0x79, 0x46, 0xef, 0x19, 0x36, 0x89, 0x07, 0xbf, 0xfe, 0x39, 0x0c, 0xda, 0x83,
-	0xc5, 0xde, 0xd0, 0xf6, 0xf0, 0xa9, 0x58, 0x1e, 0x77, 0x66, 0xe2, 0xd6, 0x39, 0x0f, 0x5d, 0xf4,
-	0xaf, 0x3c, 0x85, 0x02, 0xff, 0x1a, 0x3d, 0x86, 0xec, 0x88, 0x06, 0x2c, 0xfd, 0x8b, 0x80, 0x19,
-	0x44, 0xe5, 0x6f, 0x09, 0xfe, 0x3f, 0xc5, 0x04, 0x7d, 0x1c, 0xdf, 0xa2, 0xe8, 0x36, 0x44, 0x51,
-	0x85, 0x0d, 0xfc, 0x04, 0x32, 0x1e, 0x11, 0x9d, 0x64, 0x44, 0xb1, 0xf6, 0xf0, 0x32, 0x61, 0xd2,
-	0x05, 0xc8, 0x4e, 0xe9, 0x14, 0x4a, 0x6b, 0x42, 0x3e, 0x98, 0x41, 0x0a, 0x64, 0xb7, 0x5b, 0xed,
-	0xfd, 0x6e, 0x29, 0x85, 0x00, 0x16, 0xf6, 0xf6, 0xbb, 0xe4, 0x59, 0x42, 0x2a, 0xe4, 0x5a, 0xcd,
-	0xfd, 0xae, 0xbe, 0xbe, 0x53, 0x92, 0xd1, 0x0d, 0xb8, 0x5e, 0xdf, 0x6b, 0x75, 0x9b, 0x9f, 0x77,
-	0x0f, 0x1a, 0xcd, 0x76, 0xb3, 0xd5, 0x68, 0xb6, 0xba, 0xa5, 0xb4, 0x56, 0x87, 0x6b, 0x51, 0xb2,
-	0x37, 0x61, 0x61, 0xe4, 0xe0, 0x23, 0xeb, 0xd4, 0xcf, 0x11, 0x7f, 0x84, 0xca, 0x90, 0xb3, 0xdc,
-	0xdd, 0xf1, 0xc0, 0xb3, 0x28, 0x8f, 0xbc, 0x1e, 0x0c, 0xb5, 0xbf, 0x24, 0x50, 0x77, 0x2c, 0xfb,
-	0x58, 0xc7, 0xcf, 0xc7, 0xd8, 0xf5, 0xd0, 0x4a, 0x70, 0x0f, 0x49, 0x13, 0x2a, 0x95, 0x33, 0xf4,
-	0x2f, 0x20, 0xfa, 0x1c, 0xdc, 0x3f, 0x3f, 0x4a, 0xa0, 0x72, 0xd3, 0xe8, 0x01, 0xe4, 0x07, 0x96,
-	0x7d, 0x1c, 0xe6, 0x6c, 0xb1, 0x76, 0x23, 0x06, 0x47, 0x65, 0x09, 0xcd, 0xd0, 0x7d, 0x48, 0x5b,
-	0xe6, 0x83, 0x24, 0x9d, 0x1e, 0xb1, 0x63, 0xe6, 0x35, 0xbf, 0x5b, 0xb9, 0xc8, 0xbc, 0xa6, 0xfd,
-	0x21, 0xb1, 0x1e, 0x6f, 0x7f, 0x64, 0xd2, 0x36, 0x8c, 0x85, 0xb9, 0x26, 0x52, 0xbe, 0x1b, 0xdb,
-	0x62, 0xc1, 0x7c, 0x12, 0xf1, 0x53, 0x91, 0xf7, 0xc5, 0xdd, 0xc7, 0xcc, 0x16, 0x2c, 0x63, 0x1a,
-	0x9e, 0xe1, 0x4b, 0x50, 0x16, 0xdc, 0xfc, 0x05, 0x1a, 0x86, 0x67, 0xe8, 0xd4, 0x8a, 0x32, 0x22,
-	0x4c, 0xe7, 0x60, 0x14, 0x33, 0xbf, 0x12, 0x46, 0x49, 0xfa, 0x70, 0x39, 0x49, 0x1f, 0xfe, 0x9b,
-	0x04, 0x25, 0xee, 0xe3, 0x82, 0xad, 0xbf, 0x2a, 0x12, 0xba, 0x33, 0xed, 0x53, 0x64, 0x2a, 0x1f,
-	0xe7, 0x0a, 0xf9, 0xbc, 0x0d, 0x45, 0x07, 0x3f, 0x1f, 0x5b, 0x0e, 0x36, 0x37, 0x2d, 0x3c, 0x30,
-	0xd9, 0x99, 0xab, 0xe8, 0x91, 0x59, 0xca, 0x84, 0xeb, 0xf9, 0x13, 0x30, 0x89, 0x5a, 0x5f, 0x09,
-	0x93, 0x19, 0xb9, 0x96, 0x94, 0xc9, 0xef, 0x7e, 0xdd, 0xd4, 0x1d, 0x3c, 0x4f, 0xdd, 0x08, 0xe6,
-	0x93, 0xb8, 0xb8, 0x22, 0x97, 0x0a, 0xe4, 0x09, 0x44, 0xeb, 0xbc, 0xa3, 0x0b, 0xc7, 0xc1, 0xbb,
-	0x6e, 0x70, 0x14, 0xfb, 0xef, 0xe8, 0xa1, 0x11, 0x94, 0x4c, 0x3a, 0x51, 0xc9, 0x7c, 0x27, 0x41,
-	0x51, 0xdc, 0x5d, 0x54, 0x87, 0xa2, 0x2d, 0xa8, 0x94, 0x44, 0xc8, 0x88, 0x8b, 0x10, 0xbd, 0x1c,
-	0x89, 0xbe, 0x0c, 0x39, 0x72, 0x53, 0x75, 0x8d, 0x3e, 0x0d, 0x52, 0xd1, 0x83, 0xa1, 0xb6, 0xc6,
-	0xae, 0xfa, 0x6d, 0xa1, 0xef, 0x4e, 0x5b, 0x66, 0xa2, 0x76, 0x9d, 0xd8, 0x69, 0xa4, 0x31, 0x12,
-	0x23, 0x29, 0x82, 0x6c, 0x99, 0x7e, 0xd7, 0x2d, 0x5b, 0xe6, 0x2c, 0xed, 0xb4, 0x5f, 0x65, 0x50,
-	0x39, 0x8d, 0x48, 0x07, 0x64, 0xae, 0x3b, 0x7d, 0xb6, 0xbc, 0xa4, 0xb3, 0x01, 0x99, 0x75, 0xe9,
-	0x2c, 0xcb, 0x0f, 0x36, 0x40, 0xab, 0x90, 0x33, 0x9f, 0x7c, 0x69, 0x90, 0x79, 0xd6, 0x1d, 0xbc,
-	0x35, 0x4d, 0xfa, 0x6a, 0x83, 0xd9, 0xb1, 0xc6, 0x2e, 0xf0, 0x22, 0x00, 0xae, 0x0f, 0x90, 0xb9,
-	0x00, 0xa0, 0x23, 0x00, 0xf8, 0x5e, 0x95, 0x15, 0x28, 0xf0, 0xc8, 0x73, 0xb5, 0x84, 0x2b, 0x50,
-	0xe8, 0xcc, 0xe1, 0xcb, 0x77, 0x83, 0x77, 0x37, 0x21, 0x1f, 0x5c, 0x5e, 0xe4, 0xda, 0xee, 0x6c,
-	0xef, 0xb6, 0x77, 0x9a, 0xa5, 0x14, 0x2a, 0x02, 0x7c, 0xd6, 0x5c, 0x7f, 0x72, 0xb0, 0xb9, 0xad,
-	0x77, 0xc8, 0x35, 0x7e, 0x0d, 0x54, 0x3a, 0xee, 0x34, 0xeb, 0x7b, 0xad, 0x46, 0x49, 0x46, 0x8b,
-	0xa0, 0xd0, 0x89, 0x8d, 0xbd, 0xee, 0xa3, 0x52, 0xba, 0xf6, 0x67, 0x16, 0x54, 0x5a, 0xf4, 0x8c,
-	0x31, 0x6a, 0x83, 0xca, 0x8a, 0x86, 0x4c, 0xba, 0xe8, 0xf6, 0xec, 0x92, 0xaa, 0xbc, 0x7e, 0xc1,
-	0x57, 0xa2, 0x96, 0x22, 0x88, 0xec, 0xb0, 0x9f, 0x86, 0x28, 0x5c, 0x05, 0x49, 0x10, 0x5b, 0xa0,
-	0x36, 0xf0, 0x00, 0x07, 0x88, 0xb7, 0x66, 0x24, 0xa8, 0x9b, 0x2c, 0xc2, 0xc5, 0x2d, 0xec, 0x51,
-	0x30, 0xd6, 0x19, 0xbe, 0x36, 0xf3, 0x4c, 0xac, 0xdc, 0x9e, 0xfd, 0x53, 0x25, 0x44, 0xa4, 0x7d,
-	0xec, 0x24, 0xc4, 0xe8, 0x7d, 0x11, 0x41, 0x8c, 0xfd, 0xd9, 0xd2, 0x52, 0x48, 0x87, 0xc5, 0x8e,
-	0x80, 0x78, 0x7b, 0xf6, 0x95, 0x1a, 0xe1, 0x1d, 0xff, 0x8b, 0xa1, 0xa5, 0xd0, 0x63, 0xc8, 0xb5,
-	0x9d, 0x61, 0x0f, 0xbb, 0x57, 0xa0, 0x61, 0x1d, 0x32, 0x24, 0x1f, 0x51, 0x79, 0x5a, 0xbb, 0x96,
-	0x04, 0x64, 0x0b, 0x8a, 0x5b, 0x58, 0x68, 0x27, 0xc5, 0x6f, 0x44, 0xfa, 0x6b, 0x31, 0x02, 0x14,
-	0xff, 0x26, 0xd2, 0x52, 0x1b, 0x99, 0xa7, 0xf2, 0xe8, 0xf0, 0x70, 0x81, 0xfe, 0xa0, 0x7c, 0xf7,
-	0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x92, 0x20, 0x57, 0x15, 0xb6, 0x14, 0x00, 0x00,
+	// 1976 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x59, 0x4f, 0x73, 0x23, 0x47,
+	0x15, 0xf7, 0xcc, 0x48, 0xb6, 0xf5, 0x64, 0xcb, 0xe3, 0x4e, 0xb2, 0x11, 0xc3, 0xc6, 0x71, 0xa6,
+	0x42, 0xca, 0x59, 0x76, 0x0d, 0x11, 0x14, 0x05, 0x86, 0x65, 0x57, 0x96, 0xc6, 0x8e, 0x76, 0xe5,
+	0x91, 0x33, 0x92, 0x20, 0xec, 0xc5, 0xcc, 0x6a, 0x7a, 0xb5, 0x13, 0xcb, 0x23, 0x65, 0x66, 0xbc,
+	0xb1, 0x53, 0xc5, 0x05, 0x6e, 0x50, 0x45, 0x41, 0x15, 0x87, 0x70, 0xa1, 0x0a, 0x2a, 0x45, 0xe5,
+	0x48, 0x15, 0x77, 0x8a, 0x1b, 0x9c, 0xe0, 0x63, 0xf0, 0x01, 0xc2, 0x81, 0x23, 0xd5, 0xdd, 0x33,
+	0xa3, 0xee, 0x19, 0x49, 0x96, 0xbc, 0xd9, 0xca, 0x6d, 0xfa, 0xcd, 0x7b, 0xbf, 0x7e, 0x7f, 0xbb,
+	0x5f, 0x77, 0x03, 0xf2, 0x86, 0x0e, 0x3e, 0x09, 0xb0, 0xff, 0xcc, 0xed, 0xe1, 0xdd, 0x91, 0x3f,
+	0x0c, 0x87, 0xa8, 0x48, 0x68, 0x11, 0x49, 0x5f, 0x81, 0xbc, 0x71, 0x36, 0x0a, 0x2f, 0xf5, 0x3b,
+	0xb0, 0xd9, 0xc6, 0x41, 0xe0, 0x0e, 0xbd, 0x86, 0x83, 0xbd, 0xd0, 0x7d, 0xe2, 0x62, 0x1f, 0x95,
+	0x61, 0x25, 0x60, 0xc4, 0xb2, 0xb4, 0x2d, 0xed, 0x14, 0xac, 0x78, 0xa8, 0xff, 0x14, 0xd6, 0x9b,
+	0xd8, 0x0e, 0xb0, 0x85, 0x83, 0xd1, 0xd0, 0x0b, 0x30, 0xba, 0x03, 0xb9, 0xc7, 0x76, 0x80, 0x29,
+	0x5f, 0xb1, 0xf2, 0x95, 0x5d, 0x6e, 0x92, 0xdd, 0x7d, 0x8e, 0xd1, 0xa2, 0x6c, 0x68, 0x1b, 0x8a,
+	0x03, 0x22, 0x7f, 0xe4, 0x0e, 0x06, 0x6e, 0x50, 0x96, 0xb7, 0xa5, 0x1d, 0xc5, 0xe2, 0x49, 0xfa,
+	0x9f, 0x24, 0x78, 0xe9, 0xd8, 0x1f, 0xf6, 0x70, 0x10, 0x1c, 0xfa, 0xf6, 0xe8, 0xa9, 0x85, 0x3f,
+	0x3c, 0xc7, 0x41, 0x88, 0xee, 0x80, 0xe2, 0x3a, 0x41, 0x59, 0xda, 0x56, 0x76, 0x8a, 0x95, 0xaf,
+	0x0a, 0xf3, 0x98, 0x43, 0x07, 0x8f, 0xb5, 0xb7, 0x08, 0x1f, 0x31, 0xc1, 0x0d, 0xb1, 0x6f, 0x87,
+	0x98, 0x4e, 0xb2, 0x6a, 0xc5, 0x43, 0x74, 0x13, 0x0a, 0x23, 0x1f, 0xf7, 0x5c, 0x6a, 0x9e, 0xb2,
+	0x2d, 0xed, 0x48, 0xd6, 0x98, 0x80, 0xde, 0x84, 0xf5, 0x33, 0xfb, 0xa2, 0x41, 0x79, 0xdd, 0xa1,
+	0x17, 0x94, 0x73, 0xdb, 0xd2, 0x4e, 0xde, 0x12, 0x89, 0xfa, 0xdf, 0x24, 0x78, 0x59, 0x54, 0xf2,
+	0x7a, 0xee, 0xf8, 0x1e, 0x14, 0x7a, 0xf6, 0x60, 0xd0, 0xf2, 0x1d, 0xec, 0x97, 0xe5, 0xab, 0x4d,
+	0x1b, 0x73, 0xa3, 0x2d, 0x00, 0x77, 0xac, 0xa5, 0x42, 0xb5, 0xe4, 0x28, 0xc4, 0xcc, 0xde, 0xd0,
+	0x7b, 0x86, 0xfd, 0x3e, 0x76, 0xa8, 0x11, 0xab, 0xd6, 0x98, 0xa0, 0xff, 0x45, 0x86, 0xcd, 0xe3,
+	0xa1, 0x1f, 0xb6, 0x43, 0x3b, 0xbc, 0x76, 0x30, 0xab, 0x90, 0x77, 0x43, 0x7c, 0x16, 0x44, 0x9a,
+	0x7f, 0x5d, 0xe0, 0xcf, 0xa0, 0xef, 0x76, 0x3d, 0x37, 0x4c, 0x10, 0x98, 0xa4, 0xf6, 0x99, 0x04,
+	0x6b, 0x3c, 0x7d, 0x51, 0x15, 0xbe, 0x0f, 0xe0, 0x26, 0xee, 0xa1, 0x91, 0x4e, 0x7b, 0x90, 0xe8,
+	0xc1, 0x79, 0x90, 0x63, 0x47, 0xb7, 0x21, 0x1f, 0x10, 0x0d, 0xa9, 0xf7, 0x8a, 0x95, 0x1b, 0x53,
+	0xf4, 0x67, 0x4c, 0xfa, 0x2f, 0x65, 0x50, 0x49, 0x38, 0x9a, 0x6e, 0x70, 0x6d, 0x75, 0xef, 0x8b,
+	0x1e, 0xbb, 0x95, 0x89, 0x35, 0x0f, 0x3e, 0xc9, 0x61, 0x24, 0x3f, 0x3d, 0x7c, 0x11, 0x1e, 0xdb,
+	0x7d, 0xdc, 0x19, 0x9e, 0x62, 0x96, 0xc1, 0x05, 0x4b, 0x24, 0x6a, 0x27, 0x29, 0xaf, 0x8a, 0x6e,
+	0x92, 0x26, 0xb8, 0x29, 0x95, 0x68, 0xbc, 0x9b, 0x10, 0xe4, 0x3c, 0xfb, 0x8c, 0xd5, 0x51, 0xc1,
+	0xa2, 0xdf, 0x34, 0x7f, 0x88, 0xc8, 0x8b, 0xcb, 0x9f, 0x0c, 0xfa, 0x97, 0x94, 0x3f, 0x33, 0x1c,
+	0x33, 0x33, 0x7f, 0xc6, 0xfa, 0x47, 0xf9, 0xf3, 0x0b, 0x19, 0x10, 0x49, 0xaa, 0xa3, 0xa1, 0xe3,
+	0x3e, 0xb9, 0xbc, 0xae, 0xc2, 0xfb, 0xa2, 0xcf, 0x6e, 0x67, 0x72, 0x56, 0x84, 0x9f, 0xe8, 0xb4,
+	0x8f, 0x17, 0xce, 0x8e, 0x19, 0x45, 0x14, 0xeb, 0x2f, 0xcf, 0xa5, 0xbf, 0xfe, 0x2f, 0x19, 0x10,
+	0x71, 0xcd, 0x0b, 0xf4, 0x42, 0x16, 0x7e, 0x62, 0x25, 0x6d, 0x01, 0xf8, 0xc3, 0xc1, 0x00, 0x3b,
+	0xfb, 0x76, 0xef, 0x94, 0x86, 0x70, 0xd5, 0xe2, 0x28, 0xda, 0xa7, 0xe9, 0xd4, 0xba, 0x0b, 0xc5,
+	0xb1, 0xdd, 0x73, 0xed, 0x44, 0x3c, 0xff, 0x82, 0x8e, 0x42, 0x6f, 0x41, 0xc9, 0x39, 0x67, 0x8b,
+	0xf9, 0x91, 0xdb, 0xf3, 0x87, 0x6c, 0x8d, 0x57, 0xac, 0x14, 0x55, 0xff, 0x19, 0xac, 0xf1, 0xd2,
+	0xe8, 0x1b, 0xb0, 0x4c, 0xf2, 0xed, 0x3c, 0xa0, 0xbe, 0x2c, 0x55, 0x5e, 0x15, 0x26, 0x6a, 0xd3,
+	0x5f, 0xb5, 0xa1, 0x83, 0xad, 0x88, 0x8d, 0x6c, 0xc9, 0x0e, 0x0e, 0x7a, 0xbe, 0x3b, 0x22, 0xa8,
+	0x51, 0x95, 0xf3, 0x24, 0xa4, 0xc1, 0xea, 0x19, 0x0e, 0x02, 0xbb, 0x8f, 0x89, 0x12, 0xca, 0x4e,
+	0xc1, 0x4a, 0xc6, 0xfa, 0x27, 0x32, 0x14, 0x92, 0x54, 0x4f, 0x96, 0x0a, 0x69, 0xbc, 0x54, 0xa0,
+	0x9d, 0xb8, 0x4a, 0x98, 0xe1, 0x28, 0xa3, 0x4f, 0x5c, 0x21, 0xe8, 0x3b, 0x00, 0xa3, 0x78, 0xd5,
+	0x65, 0x33, 0x4d, 0x5f, 0x94, 0x39, 0x4e, 0x74, 0x1f, 0x56, 0x7b, 0x4f, 0xdd, 0x81, 0xe3, 0x63,
+	0xaf, 0x9c, 0xa3, 0x52, 0x6f, 0x4e, 0x2e, 0xc5, 0xdd, 0x5a, 0xc4, 0x66, 0x78, 0xa1, 0x7f, 0x69,
+	0x25, 0x52, 0x5a, 0x1b, 0xd6, 0x85, 0x5f, 0x48, 0x05, 0xe5, 0x14, 0x5f, 0x46, 0x76, 0x90, 0x4f,
+	0x52, 0xec, 0xcf, 0xec, 0xc1, 0x79, 0x6c, 0xc6, 0xd4, 0x62, 0xa7, 0x4c, 0x7b, 0xf2, 0x77, 0x25,
+	0xfd, 0x10, 0x0a, 0x89, 0xbe, 0x04, 0x30, 0xb4, 0xfb, 0x31, 0x60, 0x68, 0xf7, 0xe7, 0xf7, 0x8b,
+	0xfe, 0x5b, 0x19, 0xf2, 0x0c, 0xe5, 0x1e, 0x14, 0xbc, 0xf3, 0xb3, 0x1f, 0x91, 0x29, 0xe2, 0x04,
+	0x7c, 0x23, 0x2b, 0xb7, 0x6b, 0xc6, 0x3c, 0xcc, 0xce, 0xb1, 0x0c, 0x7a, 0x17, 0xd6, 0x82, 0xd0,
+	0x77, 0xbd, 0x7e, 0x84, 0x21, 0x4f, 0x70, 0x17, 0xc3, 0x68, 0x73, 0x6c, 0x0c, 0x46, 0x90, 0xd4,
+	0x7e, 0x00, 0x25, 0x71, 0x9a, 0x09, 0x3e, 0x7b, 0x99, 0xf7, 0x99, 0xc4, 0xf9, 0x46, 0xbb, 0x07,
+	0x9b, 0x99, 0x09, 0xae, 0x02, 0x28, 0xf0, 0xce, 0xfd, 0x00, 0x50, 0x9b, 0xe9, 0x5b, 0xe7, 0x32,
+	0x35, 0x95, 0xcb, 0x52, 0x36, 0x97, 0x2b, 0x90, 0xa7, 0xb6, 0x46, 0x96, 0xdf, 0xcc, 0x84, 0x91,
+	0x83, 0xb3, 0x18, 0xab, 0xfe, 0x69, 0x0e, 0x36, 0x52, 0xbf, 0x48, 0x4d, 0x10, 0x52, 0xe7, 0x72,
+	0x14, 0x67, 0x7b, 0x32, 0x46, 0x16, 0x14, 0x48, 0x09, 0x93, 0xe0, 0xc7, 0xf3, 0x7c, 0x7b, 0xd6,
+	0x3c, 0xbb, 0xd5, 0x30, 0xb4, 0x7b, 0x4f, 0xb1, 0x43, 0x24, 0xf8, 0xf9, 0xc7, 0x30, 0xa8, 0x05,
+	0xeb, 0xbd, 0xa1, 0x17, 0xe2, 0x0b, 0xb1, 0x3c, 0xde, 0x9e, 0x89, 0x5b, 0xe3, 0x24, 0x2c, 0x51,
+	0x5e, 0x7b, 0x04, 0x6b, 0xfc, 0x6f, 0xf4, 0x00, 0xf2, 0x23, 0xaa, 0xb0, 0xf4, 0x1c, 0x0a, 0x33,
+	0x08, 0xed, 0x73, 0x09, 0x5e, 0x9d, 0xc2, 0x82, 0x7e, 0x98, 0x0d, 0x51, 0x3a, 0x0c, 0x69, 0x54,
+	0x21, 0x80, 0xef, 0x41, 0x2e, 0xbc, 0x1c, 0xb1, 0x8c, 0x28, 0x55, 0xee, 0x5e, 0x47, 0x4d, 0x3a,
+	0x01, 0x89, 0x94, 0x45, 0xa1, 0x74, 0x03, 0x56, 0x63, 0x0a, 0x2a, 0x40, 0xbe, 0x61, 0x1e, 0x77,
+	0x3b, 0xea, 0x12, 0x02, 0x58, 0x6e, 0x75, 0x3b, 0xe4, 0x5b, 0x42, 0x45, 0x58, 0x31, 0x8d, 0x6e,
+	0xc7, 0xaa, 0x36, 0x55, 0x19, 0xbd, 0x02, 0x9b, 0xb5, 0x96, 0xd9, 0x31, 0xde, 0xef, 0x9c, 0xd4,
+	0x8d, 0x63, 0xc3, 0xac, 0x1b, 0x66, 0x47, 0x55, 0xf4, 0x1a, 0x6c, 0xa4, 0x8d, 0xbd, 0x01, 0xcb,
+	0x23, 0x1f, 0x3f, 0x71, 0x2f, 0xa2, 0x1c, 0x89, 0x46, 0xf4, 0x74, 0x12, 0x1c, 0x9d, 0x0f, 0x42,
+	0x37, 0x39, 0x9d, 0xb0, 0xa1, 0xfe, 0x73, 0x19, 0x8a, 0x4d, 0xd7, 0x3b, 0x8d, 0x8f, 0x3d, 0x7b,
+	0xf1, 0x4e, 0x27, 0x4d, 0xa8, 0x54, 0x8e, 0x31, 0xda, 0xe2, 0xe8, 0x77, 0xbc, 0xc3, 0xdd, 0x80,
+	0x65, 0x3b, 0x1c, 0x9e, 0xb9, 0xbd, 0x68, 0x92, 0x68, 0xa4, 0xfd, 0x41, 0x82, 0x22, 0xc7, 0x8e,
+	0xde, 0x81, 0xd5, 0x81, 0xeb, 0x9d, 0x26, 0xb9, 0x5c, 0xaa, 0xbc, 0x92, 0x99, 0x86, 0xba, 0x2b,
+	0x61, 0x63, 0xa7, 0xb1, 0x77, 0xe6, 0x69, 0xb8, 0x09, 0x1f, 0x63, 0xaf, 0x94, 0x95, 0xb9, 0xd8,
+	0x2b, 0xfa, 0x7f, 0x24, 0xd6, 0x5d, 0x76, 0x47, 0x0e, 0x6d, 0x00, 0x99, 0x9a, 0xf7, 0x45, 0x57,
+	0x64, 0x9b, 0x67, 0x81, 0x7d, 0x11, 0x87, 0x5c, 0x88, 0xfe, 0x78, 0xae, 0x6e, 0xf9, 0x36, 0xe4,
+	0x1c, 0x3b, 0xb4, 0x23, 0xd7, 0x94, 0x05, 0xb1, 0x68, 0x82, 0xba, 0x1d, 0xda, 0x16, 0xe5, 0xd2,
+	0xff, 0x2d, 0xb1, 0x73, 0xd8, 0x02, 0x96, 0x66, 0xd8, 0x27, 0x58, 0xba, 0xb8, 0x45, 0xf3, 0x1c,
+	0x93, 0xe4, 0x79, 0x8e, 0x49, 0xff, 0x90, 0x40, 0x1d, 0x13, 0xa3, 0xf9, 0xef, 0x89, 0x06, 0xbd,
+	0x3d, 0x05, 0x62, 0xba, 0x3d, 0xfe, 0x17, 0x68, 0xcf, 0x5b, 0x50, 0xf2, 0xf1, 0x87, 0xe7, 0xae,
+	0x8f, 0x9d, 0x03, 0x17, 0x0f, 0x1c, 0xb6, 0x46, 0x17, 0xac, 0x14, 0x55, 0xff, 0xa3, 0x04, 0x1b,
+	0xe3, 0x33, 0x19, 0x9b, 0x78, 0xea, 0xcd, 0x08, 0xd9, 0x10, 0xbc, 0x78, 0x43, 0x60, 0xbb, 0x55,
+	0x32, 0x26, 0xad, 0x26, 0x69, 0x85, 0x8e, 0xd9, 0x52, 0xc0, 0x4e, 0x6c, 0x1c, 0x85, 0xc8, 0x8e,
+	0xec, 0x3e, 0x6e, 0xbb, 0x1f, 0xe3, 0xe8, 0xbe, 0x21, 0x19, 0xd3, 0xeb, 0x8a, 0xe4, 0xb0, 0x97,
+	0xa7, 0xa2, 0x63, 0x02, 0xf5, 0x36, 0x77, 0x52, 0x9a, 0xc3, 0xdb, 0x69, 0xee, 0x2f, 0xc4, 0xdb,
+	0x33, 0xea, 0x61, 0x5e, 0x6f, 0x7f, 0xa2, 0xb0, 0x9a, 0xaf, 0xf9, 0x78, 0x91, 0x9a, 0x17, 0xd8,
+	0x17, 0xa8, 0x79, 0x3e, 0x92, 0x8a, 0x10, 0x49, 0xed, 0xd7, 0xb2, 0x68, 0x7e, 0x14, 0x59, 0x73,
+	0xdc, 0xd8, 0x26, 0xe3, 0x99, 0x51, 0x8f, 0x57, 0x02, 0x65, 0x9e, 0x95, 0x00, 0x3d, 0x02, 0x38,
+	0x23, 0x3b, 0x00, 0xeb, 0x1a, 0x58, 0x1b, 0xbb, 0x37, 0xbf, 0xb9, 0xbb, 0x47, 0x89, 0x30, 0xeb,
+	0xd6, 0x38, 0x34, 0xed, 0x2e, 0x6c, 0xa4, 0x7e, 0x5f, 0xd5, 0x6b, 0xe5, 0xf9, 0x5e, 0xeb, 0x57,
+	0x12, 0x94, 0xc4, 0x7a, 0x42, 0x35, 0x28, 0x79, 0x42, 0xcc, 0xe7, 0x49, 0x8b, 0x94, 0x88, 0xe0,
+	0x58, 0x39, 0xe5, 0xd8, 0x32, 0xac, 0x90, 0x5e, 0xa2, 0x63, 0xf7, 0xe3, 0xf0, 0x44, 0x43, 0xfd,
+	0x3e, 0xab, 0xca, 0x86, 0x70, 0xb4, 0x5a, 0xe4, 0x6e, 0x50, 0xff, 0x00, 0x4a, 0x22, 0x19, 0x95,
+	0x40, 0x76, 0x1d, 0x6a, 0x42, 0xde, 0x92, 0x5d, 0x67, 0x66, 0x58, 0xa7, 0x26, 0x4e, 0x72, 0xfa,
+	0xc9, 0x71, 0x17, 0x25, 0x7f, 0x95, 0xa1, 0xc8, 0x05, 0x9b, 0x78, 0xd9, 0xa9, 0xfa, 0x7d, 0xa6,
+	0xac, 0x64, 0xb1, 0x01, 0xa1, 0x06, 0x94, 0xca, 0x6a, 0x83, 0x0d, 0xd0, 0x3d, 0x58, 0x71, 0x1e,
+	0x7e, 0x64, 0xfb, 0xfd, 0xb8, 0xdb, 0xfb, 0xda, 0xb4, 0x1c, 0xda, 0xad, 0x33, 0x3e, 0x16, 0xfa,
+	0x58, 0x8a, 0x00, 0x04, 0x11, 0x40, 0xee, 0x0a, 0x80, 0xb6, 0x00, 0x10, 0x49, 0x69, 0x7b, 0xb0,
+	0xc6, 0x23, 0x2f, 0xd4, 0xe2, 0xef, 0xc1, 0x5a, 0x7b, 0x01, 0x59, 0xbe, 0xbb, 0xbf, 0xf5, 0x67,
+	0x09, 0x60, 0x7c, 0x54, 0x25, 0x4d, 0x59, 0xd7, 0x6c, 0x1b, 0xa4, 0x29, 0x5b, 0x01, 0xb9, 0xf5,
+	0x50, 0xfd, 0xa7, 0x84, 0x54, 0x28, 0xee, 0x57, 0xeb, 0x27, 0x96, 0xf1, 0x5e, 0xd7, 0x68, 0x77,
+	0xd4, 0xdf, 0x28, 0xa8, 0x04, 0x05, 0xb3, 0xd5, 0x39, 0x39, 0x68, 0x75, 0xcd, 0xba, 0xfa, 0x3b,
+	0x05, 0xad, 0xc3, 0x6a, 0xad, 0x65, 0x1e, 0x34, 0x1b, 0xb5, 0x8e, 0xfa, 0x7b, 0x05, 0xdd, 0x80,
+	0xcd, 0x83, 0x6a, 0xa3, 0x69, 0xd4, 0x93, 0xa6, 0xad, 0xf6, 0x13, 0xf5, 0x33, 0x2a, 0x56, 0xab,
+	0x9a, 0x35, 0xa3, 0xd9, 0x34, 0xea, 0xea, 0xe7, 0x0a, 0x7a, 0x09, 0x4a, 0x0d, 0xb3, 0x63, 0x58,
+	0x66, 0xb5, 0x79, 0x62, 0x58, 0x56, 0xcb, 0x52, 0xff, 0x4b, 0x85, 0xeb, 0x46, 0xb5, 0xde, 0x6c,
+	0x98, 0xc6, 0x89, 0xf1, 0x7e, 0xcd, 0x30, 0xea, 0x46, 0x5d, 0xfd, 0x9f, 0x72, 0xeb, 0x00, 0x56,
+	0xe3, 0xee, 0x88, 0xf4, 0x8b, 0xed, 0xc6, 0xd1, 0x71, 0xd3, 0x50, 0x97, 0x50, 0x09, 0xe0, 0xc7,
+	0x46, 0xf5, 0xe1, 0xc9, 0x41, 0xc3, 0x6a, 0x93, 0xfe, 0x71, 0x03, 0x8a, 0x74, 0xdc, 0x36, 0x6a,
+	0x2d, 0xb3, 0xae, 0xca, 0x68, 0x1d, 0x0a, 0x94, 0xb0, 0xdf, 0xea, 0xbc, 0xab, 0x2a, 0x95, 0xbf,
+	0x17, 0xa0, 0x48, 0x57, 0x66, 0x16, 0x1a, 0x74, 0x0c, 0x45, 0x56, 0xea, 0x84, 0x18, 0xa0, 0xad,
+	0xd9, 0x0b, 0x81, 0xf6, 0xfa, 0x15, 0x17, 0x20, 0xfa, 0x12, 0x41, 0x64, 0x5d, 0xc3, 0x34, 0x44,
+	0xa1, 0xa7, 0x98, 0x07, 0xd1, 0x84, 0x62, 0x1d, 0x0f, 0x70, 0x8c, 0x78, 0x73, 0x46, 0xdd, 0x05,
+	0xf3, 0x69, 0xb8, 0x7e, 0x88, 0x43, 0x0a, 0xc6, 0x8e, 0x24, 0xaf, 0xcd, 0xdc, 0xb8, 0xb4, 0xad,
+	0xd9, 0xf7, 0x85, 0xfa, 0x12, 0x7a, 0x00, 0x05, 0xb2, 0x79, 0x4f, 0xd3, 0x8f, 0xdb, 0xd8, 0xb5,
+	0xd7, 0x66, 0x5e, 0xc5, 0x26, 0xda, 0xd1, 0x15, 0x74, 0x92, 0x76, 0xe9, 0x26, 0x46, 0xdb, 0x9a,
+	0xf6, 0x3b, 0x41, 0xb4, 0x60, 0xbd, 0x2d, 0x20, 0x6e, 0xcd, 0xee, 0xf3, 0xb4, 0xd7, 0x33, 0xff,
+	0x33, 0x3e, 0x7c, 0x00, 0x2b, 0xd1, 0xbb, 0xc4, 0xf3, 0xc7, 0xe3, 0x04, 0xd6, 0x23, 0xac, 0x76,
+	0xe8, 0x63, 0xfb, 0xec, 0x0a, 0xc4, 0x85, 0x2e, 0xe1, 0xf4, 0xa5, 0x6f, 0x4a, 0xa8, 0x0b, 0x6b,
+	0xfc, 0x23, 0x0a, 0xda, 0x16, 0xed, 0xcb, 0x3e, 0x02, 0x69, 0x6f, 0xcc, 0xe0, 0x48, 0xf4, 0xae,
+	0x41, 0x8e, 0xd4, 0x24, 0x2a, 0x4f, 0x3b, 0x2b, 0xcd, 0x63, 0xbc, 0x01, 0xcb, 0x5d, 0x6f, 0xf0,
+	0xdc, 0x30, 0x87, 0x50, 0x3a, 0xc4, 0xc2, 0x91, 0x50, 0xbc, 0xe7, 0xa1, 0x8f, 0x70, 0x29, 0xa0,
+	0xec, 0xbd, 0x06, 0x4b, 0x16, 0x56, 0x6c, 0xd1, 0x6b, 0x5d, 0x2a, 0x59, 0x32, 0x6f, 0x78, 0xf3,
+	0x28, 0xd7, 0x84, 0x35, 0x0b, 0x7b, 0xf8, 0xa3, 0x79, 0x21, 0x35, 0xd1, 0x13, 0xfc, 0x3b, 0xa0,
+	0xbe, 0xb4, 0x9f, 0x7b, 0x24, 0x8f, 0x1e, 0x3f, 0x5e, 0xa6, 0x8f, 0x8d, 0xdf, 0xfa, 0xff, 0x00,
+	0x31, 0xd1, 0x3a, 0x0d, 0x82, 0x1c, 0x00, 0x00,
 }